@@ -0,0 +1,13 @@
+// Command healthcheck is a standalone probe for the health endpoint of a service based on go-base.
+// It exits with 0 in case the service is healthy and with 1 otherwise. See package healthcheck for the flags.
+package main
+
+import (
+	"os"
+
+	"github.com/ThomasObenaus/go-base/health/healthcheck"
+)
+
+func main() {
+	os.Exit(healthcheck.Run(os.Args[1:], os.Stderr))
+}
@@ -0,0 +1,141 @@
+package health
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// Authorizer decides whether the caller of an endpoint is allowed to see the results of the individual checks
+// (see WithRestrictedDetails) or to change the maintenance (see WithMaintenanceAuthorizers)
+type Authorizer func(r *http.Request) bool
+
+// TokenAuthorizer authorizes callers that pass the given token as bearer token (i.e. "Authorization: Bearer <token>")
+func TokenAuthorizer(token string) Authorizer {
+	return func(r *http.Request) bool {
+		if len(token) == 0 {
+			return false
+		}
+
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "Bearer ") {
+			return false
+		}
+		return subtle.ConstantTimeCompare([]byte(strings.TrimPrefix(auth, "Bearer ")), []byte(token)) == 1
+	}
+}
+
+// CIDRAuthorizer authorizes callers whose address is part of one of the given networks (e.g. "10.0.0.0/8").
+// Only the address of the connection is considered, headers like X-Forwarded-For are ignored since they can be forged.
+func CIDRAuthorizer(cidrs ...string) (Authorizer, error) {
+	networks := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("Can't create Authorizer with invalid CIDR '%s': %w", cidr, err)
+		}
+		networks = append(networks, network)
+	}
+
+	return func(r *http.Request) bool {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return false
+		}
+
+		for _, network := range networks {
+			if network.Contains(ip) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// ErrorSanitizer is called for each error message of the given check before it leaves the service (e.g. to remove hostnames or user names).
+// It returns the message that is reported instead.
+type ErrorSanitizer func(check string, message string) string
+
+// isAuthorized returns true in case the caller is allowed to see the results of the individual checks
+func (m *Monitor) isAuthorized(r *http.Request) bool {
+	if !m.restrictDetails {
+		return true
+	}
+	return isAuthorizedBy(r, m.authorizers)
+}
+
+// isAuthorizedBy returns true in case one of the given authorizers authorizes the caller.
+// In contrast to isAuthorized no caller is authorized in case no authorizer is given.
+func isAuthorizedBy(r *http.Request, authorizers []Authorizer) bool {
+	for _, authorize := range authorizers {
+		if authorize(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact removes the results of the individual checks in case the caller is not authorized to see them
+// and sanitizes the error messages of the remaining ones
+func (m *Monitor) redact(r *http.Request, response response) response {
+	if !m.isAuthorized(r) {
+		response.Checks = nil
+		return response
+	}
+	return m.sanitizeResponse(response)
+}
+
+func (m *Monitor) sanitizeResponse(response response) response {
+	if m.errorSanitizer == nil {
+		return response
+	}
+
+	checks := make([]check, 0, len(response.Checks))
+	for _, c := range response.Checks {
+		c.Error = m.sanitize(c.Name, c.Error)
+		c.Message = m.sanitize(c.Name, c.Message)
+		c.Checks = m.sanitizeChildren(c.Checks)
+		checks = append(checks, c)
+	}
+	response.Checks = checks
+	return response
+}
+
+func (m *Monitor) sanitizeChildren(children []childCheck) []childCheck {
+	var sanitized []childCheck
+	for _, child := range children {
+		child.Error = m.sanitize(child.Name, child.Error)
+		child.Checks = m.sanitizeChildren(child.Checks)
+		sanitized = append(sanitized, child)
+	}
+	return sanitized
+}
+
+func (m *Monitor) sanitizeHistory(response checkHistoryResponse) checkHistoryResponse {
+	if m.errorSanitizer == nil {
+		return response
+	}
+
+	for i := range response.Results {
+		response.Results[i].Error = m.sanitize(response.Name, response.Results[i].Error)
+		response.Results[i].Message = m.sanitize(response.Name, response.Results[i].Message)
+	}
+	for i := range response.Transitions {
+		response.Transitions[i].Error = m.sanitize(response.Name, response.Transitions[i].Error)
+	}
+	return response
+}
+
+// sanitize sanitizes the given (error) message of the given check, an empty message (e.g. of a healthy check) is kept as is
+func (m *Monitor) sanitize(check string, message string) string {
+	if m.errorSanitizer == nil || len(message) == 0 {
+		return message
+	}
+	return m.errorSanitizer(check, message)
+}
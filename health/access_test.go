@@ -0,0 +1,159 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TokenAuthorizer(t *testing.T) {
+
+	// GIVEN
+	authorize := TokenAuthorizer("secret")
+	authorized := httptest.NewRequest(http.MethodGet, "/health", nil)
+	authorized.Header.Set("Authorization", "Bearer secret")
+	wrongToken := httptest.NewRequest(http.MethodGet, "/health", nil)
+	wrongToken.Header.Set("Authorization", "Bearer guess")
+	anonymous := httptest.NewRequest(http.MethodGet, "/health", nil)
+
+	// WHEN + THEN
+	assert.True(t, authorize(authorized))
+	assert.False(t, authorize(wrongToken))
+	assert.False(t, authorize(anonymous))
+	assert.False(t, TokenAuthorizer("")(anonymous))
+}
+
+func Test_CIDRAuthorizer(t *testing.T) {
+
+	// GIVEN
+	authorize, err := CIDRAuthorizer("10.0.0.0/8", "::1/128")
+	require.NoError(t, err)
+	internal := httptest.NewRequest(http.MethodGet, "/health", nil)
+	internal.RemoteAddr = "10.1.2.3:4711"
+	localhost := httptest.NewRequest(http.MethodGet, "/health", nil)
+	localhost.RemoteAddr = "[::1]:4711"
+	external := httptest.NewRequest(http.MethodGet, "/health", nil)
+	external.RemoteAddr = "192.0.2.1:4711"
+	external.Header.Set("X-Forwarded-For", "10.1.2.3")
+
+	// WHEN + THEN
+	assert.True(t, authorize(internal))
+	assert.True(t, authorize(localhost))
+	assert.False(t, authorize(external))
+
+	_, err = CIDRAuthorizer("10.0.0.0")
+	assert.Error(t, err)
+}
+
+func newRestrictedMonitor(t *testing.T, options ...Option) *Monitor {
+	db, err := NewSimpleCheck("db", func() error { return fmt.Errorf("dial tcp db.internal:5432: user admin denied") })
+	require.NoError(t, err)
+	monitor, err := NewMonitor(options...)
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(db))
+	monitor.EvaluateNow()
+	return monitor
+}
+
+func Test_HealthEndpointShouldRestrictDetails(t *testing.T) {
+
+	// GIVEN
+	monitor := newRestrictedMonitor(t, WithRestrictedDetails(TokenAuthorizer("secret")))
+	anonymous := httptest.NewRequest(http.MethodGet, "/health", nil)
+	authorized := httptest.NewRequest(http.MethodGet, "/health", nil)
+	authorized.Header.Set("Authorization", "Bearer secret")
+
+	// WHEN
+	anonymousRecorder := httptest.NewRecorder()
+	monitor.Health(anonymousRecorder, anonymous)
+	authorizedRecorder := httptest.NewRecorder()
+	monitor.Health(authorizedRecorder, authorized)
+
+	// THEN
+	assert.Equal(t, http.StatusServiceUnavailable, anonymousRecorder.Code)
+	var anonymousResponse response
+	require.NoError(t, json.Unmarshal(anonymousRecorder.Body.Bytes(), &anonymousResponse))
+	assert.Equal(t, "unhealthy", anonymousResponse.Status)
+	assert.Empty(t, anonymousResponse.Checks)
+	assert.NotContains(t, anonymousRecorder.Body.String(), "db.internal")
+
+	var authorizedResponse response
+	require.NoError(t, json.Unmarshal(authorizedRecorder.Body.Bytes(), &authorizedResponse))
+	require.Len(t, authorizedResponse.Checks, 1)
+	assert.Equal(t, "dial tcp db.internal:5432: user admin denied", authorizedResponse.Checks[0].Error)
+}
+
+func Test_HistoryEndpointShouldRequireAuthorization(t *testing.T) {
+
+	// GIVEN
+	monitor := newRestrictedMonitor(t, WithRestrictedDetails())
+
+	// WHEN
+	recorder := httptest.NewRecorder()
+	monitor.History(recorder, httptest.NewRequest(http.MethodGet, "/health/history", nil))
+
+	// THEN
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	assert.NotContains(t, recorder.Body.String(), "db.internal")
+}
+
+func Test_EndpointsShouldSanitizeErrors(t *testing.T) {
+
+	// GIVEN
+	monitor := newRestrictedMonitor(t, WithErrorSanitizer(func(check string, message string) string {
+		return check + ": " + strings.Replace(message, "db.internal", "***", -1)
+	}))
+
+	// WHEN
+	healthRecorder := httptest.NewRecorder()
+	monitor.Health(healthRecorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	historyRecorder := httptest.NewRecorder()
+	monitor.History(historyRecorder, httptest.NewRequest(http.MethodGet, "/health/history", nil))
+
+	// THEN
+	assert.Contains(t, healthRecorder.Body.String(), "db: dial tcp ***:5432")
+	assert.NotContains(t, healthRecorder.Body.String(), "db.internal")
+	assert.Contains(t, historyRecorder.Body.String(), "db: dial tcp ***:5432")
+	assert.NotContains(t, historyRecorder.Body.String(), "db.internal")
+}
+
+func Test_SanitizeResponseShouldSanitizeNestedResults(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor(WithErrorSanitizer(func(check string, message string) string { return "redacted" }))
+	require.NoError(t, err)
+	resp := response{Checks: []check{{Name: "cluster", Error: "failed", Checks: []childCheck{
+		{Name: "node-1", Status: "unhealthy", Error: "node-1.internal unreachable"},
+		{Name: "node-2", Status: "healthy"},
+	}}}}
+
+	// WHEN
+	sanitized := monitor.sanitizeResponse(resp)
+
+	// THEN
+	assert.Equal(t, "redacted", sanitized.Checks[0].Error)
+	assert.Equal(t, "redacted", sanitized.Checks[0].Checks[0].Error)
+	assert.Equal(t, "", sanitized.Checks[0].Checks[1].Error)
+}
+
+func Test_RemoteHealthCheckShouldPassToken(t *testing.T) {
+
+	// GIVEN
+	downstream := newRestrictedMonitor(t, WithRestrictedDetails(TokenAuthorizer("secret")))
+	server := httptest.NewServer(http.HandlerFunc(downstream.Health))
+	defer server.Close()
+	remote, err := NewRemoteHealthCheck("orders", server.URL, WithRemoteToken("secret"))
+	require.NoError(t, err)
+
+	// WHEN
+	err = remote.IsHealthy()
+
+	// THEN
+	assert.EqualError(t, err, "Remote service is unhealthy (failing checks: db)")
+}
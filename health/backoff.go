@@ -0,0 +1,62 @@
+package health
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// backoff is the policy that stretches the interval of a check while it is failing
+type backoff struct {
+	initial    time.Duration
+	max        time.Duration
+	multiplier float64
+	// the delay is varied randomly by up to this fraction (0 <= jitter <= 1), hence several instances don't retry in lockstep
+	jitter float64
+	// returns a random number in [0,1)
+	random func() float64
+}
+
+func newBackoff(initial, max time.Duration, multiplier, jitter float64) *backoff {
+	return &backoff{
+		initial:    initial,
+		max:        max,
+		multiplier: multiplier,
+		jitter:     jitter,
+		random:     rand.Float64,
+	}
+}
+
+func (b *backoff) validate() error {
+	if b.initial <= 0 {
+		return fmt.Errorf("initial delay %s has to be greater than 0", b.initial)
+	}
+	if b.max < b.initial {
+		return fmt.Errorf("max delay %s has to be at least the initial delay %s", b.max, b.initial)
+	}
+	if b.multiplier < 1 {
+		return fmt.Errorf("multiplier %g has to be at least 1", b.multiplier)
+	}
+	if b.jitter < 0 || b.jitter > 1 {
+		return fmt.Errorf("jitter %g has to be between 0 and 1", b.jitter)
+	}
+	return nil
+}
+
+// maxDelay returns the longest delay the backoff can result in (the max delay including the jitter)
+func (b *backoff) maxDelay() time.Duration {
+	return time.Duration(float64(b.max) * (1 + b.jitter))
+}
+
+// delay returns the delay after the given number of consecutive failures (at least 1)
+func (b *backoff) delay(consecutiveFailures uint) time.Duration {
+	delay := float64(b.initial) * math.Pow(b.multiplier, float64(consecutiveFailures-1))
+	if delay > float64(b.max) {
+		delay = float64(b.max)
+	}
+
+	// vary the delay by [-jitter, +jitter)
+	delay += delay * b.jitter * (2*b.random() - 1)
+	return time.Duration(delay)
+}
@@ -0,0 +1,116 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_BackoffDelay(t *testing.T) {
+
+	// GIVEN
+	b := newBackoff(time.Second*10, time.Minute, 2, 0)
+
+	// WHEN + THEN
+	assert.Equal(t, time.Second*10, b.delay(1))
+	assert.Equal(t, time.Second*20, b.delay(2))
+	assert.Equal(t, time.Second*40, b.delay(3))
+	assert.Equal(t, time.Minute, b.delay(4))
+	assert.Equal(t, time.Minute, b.delay(100))
+}
+
+func Test_BackoffDelayWithJitter(t *testing.T) {
+
+	// GIVEN
+	b := newBackoff(time.Second*10, time.Minute, 2, 0.5)
+
+	// WHEN + THEN
+	b.random = func() float64 { return 0 }
+	assert.Equal(t, time.Second*5, b.delay(1))
+	b.random = func() float64 { return 0.5 }
+	assert.Equal(t, time.Second*10, b.delay(1))
+	b.random = func() float64 { return 0.75 }
+	assert.Equal(t, time.Second*75, b.delay(4))
+}
+
+func Test_ShouldNotRegisterWithInvalidBackoff(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("check1", func() error { return nil })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.Error(t, monitor.RegisterCheck(check, WithBackoff(0, time.Minute, 2, 0)))
+	assert.Error(t, monitor.RegisterCheck(check, WithBackoff(time.Minute, time.Second, 2, 0)))
+	assert.Error(t, monitor.RegisterCheck(check, WithBackoff(time.Second, time.Minute, 0.5, 0)))
+	assert.Error(t, monitor.RegisterCheck(check, WithBackoff(time.Second, time.Minute, 2, 1.5)))
+	assert.NoError(t, monitor.RegisterCheck(check, WithBackoff(time.Second, time.Minute, 2, 0.1)))
+}
+
+func Test_ShouldNotRegisterWithStalenessBelowMaxBackoff(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("check1", func() error { return nil })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.Error(t, monitor.RegisterCheck(check, WithStaleness(time.Minute), WithBackoff(time.Second, time.Minute*5, 2, 0)))
+	assert.Error(t, monitor.RegisterCheck(check, WithStaleness(time.Minute), WithBackoff(time.Second, time.Minute, 2, 0.5)))
+	assert.Len(t, monitor.healthChecks, 0)
+	assert.NoError(t, monitor.RegisterCheck(check, WithStaleness(time.Second*90), WithBackoff(time.Second, time.Minute, 2, 0.5)))
+}
+
+func Test_EvaluateChecksShouldBackOffWhileFailing(t *testing.T) {
+
+	// GIVEN
+	var checkErr error = fmt.Errorf("connection refused")
+	numEvaluations := 0
+	check, err := NewSimpleCheck("db", func() error {
+		numEvaluations++
+		return checkErr
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(check, WithInterval(time.Second*5), WithBackoff(time.Second*10, time.Second*30, 2, 0)))
+	start := time.Now()
+
+	// WHEN
+	result := monitor.evaluateChecks(start)
+	_, response := checkEvaluationResultToResponse(result, start, time.Second*30)
+
+	// THEN
+	assert.Equal(t, 1, numEvaluations)
+	require.Len(t, response.Checks, 1)
+	assert.Equal(t, start.Add(time.Second*10), response.Checks[0].NextEvaluationAt)
+	assert.Equal(t, float64(10000), response.Checks[0].BackoffMS)
+
+	// WHEN
+	monitor.evaluateChecks(start.Add(time.Second * 5))
+
+	// THEN
+	assert.Equal(t, 1, numEvaluations)
+
+	// WHEN
+	result = monitor.evaluateChecks(start.Add(time.Second * 10))
+
+	// THEN
+	assert.Equal(t, 2, numEvaluations)
+	assert.Equal(t, start.Add(time.Second*30), result.checkResults["db"].nextEvaluationAt)
+
+	// WHEN
+	checkErr = nil
+	result = monitor.evaluateChecks(start.Add(time.Second * 30))
+
+	// THEN
+	assert.Equal(t, 3, numEvaluations)
+	assert.Equal(t, time.Duration(0), result.checkResults["db"].backoffDelay)
+	assert.Equal(t, start.Add(time.Second*35), result.checkResults["db"].nextEvaluationAt)
+}
@@ -1,5 +1,7 @@
 package health
 
+import "context"
+
 // Check is a simple entity that represents a health check
 type Check interface {
 
@@ -11,3 +13,58 @@ type Check interface {
 	// String ... to meet the Stringer interface
 	String() string
 }
+
+// ContextCheck is a health check that is aware of a context.
+// The context is cancelled as soon as the timeout of the check is exceeded.
+type ContextCheck interface {
+
+	// IsHealthy is called to obtain the health state of the ContextCheck.
+	// It should return nil if the check is healthy.
+	// In case the check is not healthy the according error should be returned.
+	// The evaluation should be aborted as soon as the given context is done.
+	IsHealthy(ctx context.Context) error
+
+	// String ... to meet the Stringer interface
+	String() string
+}
+
+// AdaptCheck turns the given Check into a ContextCheck.
+// The Check is not aware of the context but it is still abandoned by the Monitor in case its timeout is exceeded.
+func AdaptCheck(check Check) ContextCheck {
+	if check == nil {
+		return nil
+	}
+	return checkAdapter{check: check}
+}
+
+type checkAdapter struct {
+	check Check
+}
+
+func (c checkAdapter) IsHealthy(ctx context.Context) error {
+	return c.check.IsHealthy()
+}
+
+func (c checkAdapter) String() string {
+	return c.check.String()
+}
+
+// DetailsProvider can be implemented by a Check or ContextCheck to report additional details (e.g. statistics) about its state.
+// The details are obtained right after each evaluation of the check and are reported by the health endpoint.
+type DetailsProvider interface {
+
+	// Details returns the details of the check, the values have to be serializable to JSON
+	Details() map[string]interface{}
+}
+
+// underlyingCheck returns the check that was turned into the given ContextCheck by AdaptCheck or AdaptResultCheck,
+// or the ContextCheck itself in case it was not adapted
+func underlyingCheck(check ContextCheck) interface{} {
+	switch adapter := check.(type) {
+	case checkAdapter:
+		return adapter.check
+	case resultCheckAdapter:
+		return adapter.check
+	}
+	return check
+}
@@ -0,0 +1,36 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	mock_health "github.com/ThomasObenaus/go-base/test/mocks/health"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_AdaptCheck(t *testing.T) {
+
+	// GIVEN
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	errCheck := fmt.Errorf("could not connect")
+	check := mock_health.NewMockCheck(mockCtrl)
+	check.EXPECT().String().Return("check1")
+	check.EXPECT().IsHealthy().Return(errCheck)
+
+	// WHEN
+	contextCheck := AdaptCheck(check)
+
+	// THEN
+	assert.NotNil(t, contextCheck)
+	assert.Equal(t, "check1", contextCheck.String())
+	assert.Equal(t, errCheck, contextCheck.IsHealthy(context.Background()))
+
+	// WHEN
+	contextCheck = AdaptCheck(nil)
+
+	// THEN
+	assert.Nil(t, contextCheck)
+}
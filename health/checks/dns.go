@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+// DNSOption represents an option for a DNS check
+type DNSOption func(c *dnsCheck)
+
+// WithResolver specifies the resolver that is used to look up the host (default net.DefaultResolver)
+func WithResolver(resolver *net.Resolver) DNSOption {
+	return func(c *dnsCheck) {
+		c.resolver = resolver
+	}
+}
+
+// NewDNSCheck creates a Check that is healthy as long as the given host can be resolved to at least one address
+// within the given timeout.
+func NewDNSCheck(name, host string, timeout time.Duration, options ...DNSOption) (health.Check, error) {
+	if err := validate(name, timeout); err != nil {
+		return nil, err
+	}
+
+	if len(strings.TrimSpace(host)) == 0 {
+		return nil, fmt.Errorf("Can't create Check '%s' without a host", name)
+	}
+
+	check := &dnsCheck{
+		name:     name,
+		host:     host,
+		timeout:  timeout,
+		resolver: net.DefaultResolver,
+	}
+
+	// apply the options
+	for _, opt := range options {
+		opt(check)
+	}
+
+	if check.resolver == nil {
+		return nil, fmt.Errorf("Can't create Check '%s' whose resolver is nil", name)
+	}
+	return check, nil
+}
+
+type dnsCheck struct {
+	name     string
+	host     string
+	timeout  time.Duration
+	resolver *net.Resolver
+}
+
+func (c *dnsCheck) IsHealthy() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	addresses, err := c.resolver.LookupHost(ctx, c.host)
+	if err != nil {
+		return fmt.Errorf("Unable to resolve '%s': %w", c.host, err)
+	}
+	if len(addresses) == 0 {
+		return fmt.Errorf("Host '%s' was resolved to no address", c.host)
+	}
+	return nil
+}
+
+func (c *dnsCheck) String() string {
+	return c.name
+}
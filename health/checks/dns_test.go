@@ -0,0 +1,64 @@
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DNSCheck(t *testing.T) {
+
+	// GIVEN
+	check, err := NewDNSCheck("dns", "localhost", time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, check)
+
+	// WHEN
+	err = check.IsHealthy()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "dns", check.String())
+}
+
+func Test_DNSCheckShouldFail(t *testing.T) {
+
+	// GIVEN
+	unreachableResolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+			return nil, fmt.Errorf("no dns server")
+		},
+	}
+	check, err := NewDNSCheck("dns", "service.example.invalid", time.Second, WithResolver(unreachableResolver))
+	require.NoError(t, err)
+	require.NotNil(t, check)
+
+	// WHEN
+	err = check.IsHealthy()
+
+	// THEN
+	assert.Error(t, err)
+}
+
+func Test_NewDNSCheckShouldFail(t *testing.T) {
+
+	// WHEN
+	check, err := NewDNSCheck("dns", " ", time.Second)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+
+	// WHEN
+	check, err = NewDNSCheck("dns", "localhost", time.Second, WithResolver(nil))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+}
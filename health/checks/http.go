@@ -0,0 +1,166 @@
+package checks
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+// maxBodySize is the number of bytes of the response body that are read at most (to match the body regex or to drain it)
+const maxBodySize = 1024 * 1024
+
+// HTTPOption represents an option for a HTTP check
+type HTTPOption func(c *httpCheck) error
+
+// WithMethod specifies the HTTP method used for the request (default GET)
+func WithMethod(method string) HTTPOption {
+	return func(c *httpCheck) error {
+		c.method = method
+		return nil
+	}
+}
+
+// WithExpectedStatus specifies the range of HTTP status codes (inclusive) that are regarded as healthy (default 200-299).
+// Redirects are followed, unless the range contains a redirect status (3xx).
+func WithExpectedStatus(minStatus, maxStatus int) HTTPOption {
+	return func(c *httpCheck) error {
+		if minStatus > maxStatus {
+			return fmt.Errorf("The minimal expected status %d is greater than the maximal one %d", minStatus, maxStatus)
+		}
+		c.minStatus = minStatus
+		c.maxStatus = maxStatus
+		return nil
+	}
+}
+
+// WithBodyRegex specifies a regular expression the response body has to match to be regarded as healthy
+func WithBodyRegex(expr string) HTTPOption {
+	return func(c *httpCheck) error {
+		bodyRegex, err := regexp.Compile(expr)
+		if err != nil {
+			return fmt.Errorf("Invalid body regex '%s': %w", expr, err)
+		}
+		c.bodyRegex = bodyRegex
+		return nil
+	}
+}
+
+// WithHeader adds a header that is sent with the request
+func WithHeader(key, value string) HTTPOption {
+	return func(c *httpCheck) error {
+		c.header.Add(key, value)
+		return nil
+	}
+}
+
+// WithTLSConfig specifies the TLS configuration used for HTTPS requests
+func WithTLSConfig(tlsConfig *tls.Config) HTTPOption {
+	return func(c *httpCheck) error {
+		c.tlsConfig = tlsConfig
+		return nil
+	}
+}
+
+// NewHTTPCheck creates a Check that is healthy as long as a request to the given url is answered within the given timeout
+// with an expected status code (and body).
+func NewHTTPCheck(name, rawURL string, timeout time.Duration, options ...HTTPOption) (health.Check, error) {
+	if err := validate(name, timeout); err != nil {
+		return nil, err
+	}
+
+	if _, err := url.ParseRequestURI(rawURL); err != nil {
+		return nil, fmt.Errorf("Can't create Check '%s' for the invalid url '%s': %w", name, rawURL, err)
+	}
+
+	check := &httpCheck{
+		name:      name,
+		url:       rawURL,
+		timeout:   timeout,
+		method:    http.MethodGet,
+		minStatus: http.StatusOK,
+		maxStatus: 299,
+		header:    make(http.Header),
+	}
+
+	// apply the options
+	for _, opt := range options {
+		if err := opt(check); err != nil {
+			return nil, fmt.Errorf("Can't create Check '%s': %w", name, err)
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = check.tlsConfig
+	check.client = &http.Client{Transport: transport}
+	// otherwise the expected redirect could never be observed
+	if check.minStatus <= 399 && check.maxStatus >= 300 {
+		check.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+	return check, nil
+}
+
+type httpCheck struct {
+	name      string
+	url       string
+	timeout   time.Duration
+	method    string
+	minStatus int
+	maxStatus int
+	bodyRegex *regexp.Regexp
+	header    http.Header
+	tlsConfig *tls.Config
+	client    *http.Client
+}
+
+func (c *httpCheck) IsHealthy() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, c.method, c.url, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to create request for '%s': %w", c.url, err)
+	}
+	req.Header = c.header.Clone()
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Request to '%s' failed: %w", c.url, err)
+	}
+	defer drainAndClose(resp.Body)
+
+	if resp.StatusCode < c.minStatus || resp.StatusCode > c.maxStatus {
+		return fmt.Errorf("Request to '%s' returned status %d, expected %d-%d", c.url, resp.StatusCode, c.minStatus, c.maxStatus)
+	}
+
+	if c.bodyRegex == nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodySize))
+	if err != nil {
+		return fmt.Errorf("Unable to read response of '%s': %w", c.url, err)
+	}
+	if !c.bodyRegex.Match(body) {
+		return fmt.Errorf("Response of '%s' does not match '%s'", c.url, c.bodyRegex)
+	}
+	return nil
+}
+
+func (c *httpCheck) String() string {
+	return c.name
+}
+
+// drainAndClose reads the remaining body (up to maxBodySize) before closing it, hence the connection can be reused
+func drainAndClose(body io.ReadCloser) {
+	_, _ = io.Copy(io.Discard, io.LimitReader(body, maxBodySize))
+	body.Close()
+}
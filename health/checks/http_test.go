@@ -0,0 +1,157 @@
+package checks
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ok":
+			fmt.Fprint(w, `{"status":"healthy"}`)
+		case "/auth":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "ok")
+		case "/method":
+			if r.Method != http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+		case "/slow":
+			time.Sleep(time.Millisecond * 200)
+		case "/redirect":
+			http.Redirect(w, r, "/ok", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_HTTPCheck(t *testing.T) {
+
+	// GIVEN
+	server := newTestServer()
+	defer server.Close()
+
+	tests := []struct {
+		name      string
+		path      string
+		options   []HTTPOption
+		isHealthy bool
+	}{
+		{name: "ok", path: "/ok", isHealthy: true},
+		{name: "not found", path: "/unknown", isHealthy: false},
+		{name: "expected status", path: "/unknown", options: []HTTPOption{WithExpectedStatus(404, 404)}, isHealthy: true},
+		{name: "body matches", path: "/ok", options: []HTTPOption{WithBodyRegex(`"status":\s*"healthy"`)}, isHealthy: true},
+		{name: "body does not match", path: "/ok", options: []HTTPOption{WithBodyRegex(`unhealthy`)}, isHealthy: false},
+		{name: "header missing", path: "/auth", isHealthy: false},
+		{name: "header", path: "/auth", options: []HTTPOption{WithHeader("Authorization", "Bearer secret")}, isHealthy: true},
+		{name: "method", path: "/method", options: []HTTPOption{WithMethod(http.MethodHead)}, isHealthy: true},
+		{name: "timeout", path: "/slow", isHealthy: false},
+		{name: "redirect followed", path: "/redirect", isHealthy: true},
+		{name: "redirect expected", path: "/redirect", options: []HTTPOption{WithExpectedStatus(300, 399)}, isHealthy: true},
+		{name: "redirect not expected", path: "/redirect", options: []HTTPOption{WithExpectedStatus(200, 200), WithBodyRegex(`healthy`)}, isHealthy: true},
+		{name: "redirect target not expected", path: "/redirect", options: []HTTPOption{WithExpectedStatus(302, 302), WithBodyRegex(`healthy`)}, isHealthy: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			check, err := NewHTTPCheck(test.name, server.URL+test.path, time.Millisecond*100, test.options...)
+			require.NoError(t, err)
+			require.NotNil(t, check)
+
+			// WHEN
+			err = check.IsHealthy()
+
+			// THEN
+			assert.Equal(t, test.isHealthy, err == nil, "unexpected result: %v", err)
+			assert.Equal(t, test.name, check.String())
+		})
+	}
+}
+
+func Test_HTTPCheckWithTLS(t *testing.T) {
+
+	// GIVEN
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+	certPool := x509.NewCertPool()
+	certPool.AddCert(server.Certificate())
+
+	untrusted, err := NewHTTPCheck("untrusted", server.URL, time.Second)
+	require.NoError(t, err)
+	trusted, err := NewHTTPCheck("trusted", server.URL, time.Second, WithTLSConfig(&tls.Config{RootCAs: certPool}))
+	require.NoError(t, err)
+
+	// WHEN
+	errUntrusted := untrusted.IsHealthy()
+	errTrusted := trusted.IsHealthy()
+
+	// THEN
+	assert.Error(t, errUntrusted)
+	assert.NoError(t, errTrusted)
+}
+
+func Test_HTTPCheckShouldReuseConnections(t *testing.T) {
+
+	// GIVEN
+	var numConnections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", 512*1024))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&numConnections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+	check, err := NewHTTPCheck("check", server.URL, time.Second)
+	require.NoError(t, err)
+
+	// WHEN
+	for i := 0; i < 3; i++ {
+		require.NoError(t, check.IsHealthy())
+	}
+
+	// THEN
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numConnections))
+}
+
+func Test_NewHTTPCheckShouldFail(t *testing.T) {
+
+	// WHEN
+	check, err := NewHTTPCheck("http", "not a url", time.Second)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+
+	// WHEN
+	check, err = NewHTTPCheck("http", "http://localhost", time.Second, WithBodyRegex("("))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+
+	// WHEN
+	check, err = NewHTTPCheck("http", "http://localhost", time.Second, WithExpectedStatus(500, 200))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+}
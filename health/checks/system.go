@@ -0,0 +1,136 @@
+package checks
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+// NewDiskSpaceCheck creates a Check that is unhealthy in case the free disk space (available to unprivileged users)
+// of the filesystem the given path is located on falls below minFreeBytes.
+func NewDiskSpaceCheck(name, path string, minFreeBytes uint64) (health.Check, error) {
+	if err := validateSystemCheck(name, path); err != nil {
+		return nil, err
+	}
+
+	return health.NewSimpleCheck(name, func() error {
+		usage, err := readDiskUsage(path)
+		if err != nil {
+			return err
+		}
+		if usage.freeBytes < minFreeBytes {
+			return fmt.Errorf("Only %d bytes free on '%s', expected at least %d", usage.freeBytes, path, minFreeBytes)
+		}
+		return nil
+	})
+}
+
+// NewInodeCheck creates a Check that is unhealthy in case the number of free inodes
+// of the filesystem the given path is located on falls below minFreeInodes.
+func NewInodeCheck(name, path string, minFreeInodes uint64) (health.Check, error) {
+	if err := validateSystemCheck(name, path); err != nil {
+		return nil, err
+	}
+
+	return health.NewSimpleCheck(name, func() error {
+		usage, err := readDiskUsage(path)
+		if err != nil {
+			return err
+		}
+		if usage.freeInodes < minFreeInodes {
+			return fmt.Errorf("Only %d inodes free on '%s', expected at least %d", usage.freeInodes, path, minFreeInodes)
+		}
+		return nil
+	})
+}
+
+// NewMemoryCheck creates a Check that is unhealthy in case the resident set size (RSS) of the process or the Go heap
+// exceeds maxUsage (0 < maxUsage <= 1) of the memory limit.
+// The memory limit is given by limitBytes. In case limitBytes is 0 the memory limit of the cgroup the process runs in is used.
+func NewMemoryCheck(name string, limitBytes uint64, maxUsage float64) (health.Check, error) {
+	if err := validateUsage(name, maxUsage); err != nil {
+		return nil, err
+	}
+
+	if limitBytes == 0 {
+		cgroupLimit, err := readCgroupMemoryLimit()
+		if err != nil {
+			return nil, fmt.Errorf("Can't create Check '%s' without a memory limit: %w", name, err)
+		}
+		limitBytes = cgroupLimit
+	}
+
+	maxBytes := uint64(float64(limitBytes) * maxUsage)
+	return health.NewSimpleCheck(name, func() error {
+		rss, err := readRSS()
+		if err != nil {
+			return err
+		}
+		if rss > maxBytes {
+			return fmt.Errorf("RSS of %d bytes exceeds %d bytes (%.0f%% of %d bytes)", rss, maxBytes, maxUsage*100, limitBytes)
+		}
+
+		var memStats runtime.MemStats
+		runtime.ReadMemStats(&memStats)
+		if memStats.HeapAlloc > maxBytes {
+			return fmt.Errorf("Heap of %d bytes exceeds %d bytes (%.0f%% of %d bytes)", memStats.HeapAlloc, maxBytes, maxUsage*100, limitBytes)
+		}
+		return nil
+	})
+}
+
+// NewGoroutineCheck creates a Check that is unhealthy in case the number of goroutines exceeds maxGoroutines
+func NewGoroutineCheck(name string, maxGoroutines int) (health.Check, error) {
+	if maxGoroutines <= 0 {
+		return nil, fmt.Errorf("Can't create Check '%s' with a maximum of %d goroutines", name, maxGoroutines)
+	}
+
+	return health.NewSimpleCheck(name, func() error {
+		numGoroutines := runtime.NumGoroutine()
+		if numGoroutines > maxGoroutines {
+			return fmt.Errorf("%d goroutines exceed the maximum of %d", numGoroutines, maxGoroutines)
+		}
+		return nil
+	})
+}
+
+// NewFileDescriptorCheck creates a Check that is unhealthy in case the number of open file descriptors of the process
+// exceeds maxUsage (0 < maxUsage <= 1) of its limit (RLIMIT_NOFILE).
+func NewFileDescriptorCheck(name string, maxUsage float64) (health.Check, error) {
+	if err := validateUsage(name, maxUsage); err != nil {
+		return nil, err
+	}
+
+	return health.NewSimpleCheck(name, func() error {
+		open, limit, err := readFileDescriptorUsage()
+		if err != nil {
+			return err
+		}
+		maxOpen := uint64(float64(limit) * maxUsage)
+		if open > maxOpen {
+			return fmt.Errorf("%d open file descriptors exceed %d (%.0f%% of the limit %d)", open, maxOpen, maxUsage*100, limit)
+		}
+		return nil
+	})
+}
+
+type diskUsage struct {
+	freeBytes  uint64
+	freeInodes uint64
+}
+
+func validateSystemCheck(name, path string) error {
+	if len(strings.TrimSpace(path)) == 0 {
+		return fmt.Errorf("Can't create Check '%s' without a path", name)
+	}
+	return nil
+}
+
+func validateUsage(name string, maxUsage float64) error {
+	if maxUsage <= 0 || maxUsage > 1 {
+		return fmt.Errorf("Can't create Check '%s' with a maximal usage of %f, it has to be in (0,1]", name, maxUsage)
+	}
+	return nil
+}
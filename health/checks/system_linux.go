@@ -0,0 +1,100 @@
+//go:build linux
+
+package checks
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// cgroup v1 reports a huge value (close to max int64) in case no limit is set
+const cgroupV1Unlimited = uint64(1) << 62
+
+// the files the cgroup memory limit is read from (variables to be able to replace them in tests)
+var (
+	cgroupV2MemoryLimitFile = "/sys/fs/cgroup/memory.max"
+	cgroupV1MemoryLimitFile = "/sys/fs/cgroup/memory/memory.limit_in_bytes"
+)
+
+func readDiskUsage(path string) (diskUsage, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return diskUsage{}, fmt.Errorf("Unable to obtain the disk usage of '%s': %w", path, err)
+	}
+
+	return diskUsage{
+		freeBytes:  stat.Bavail * uint64(stat.Bsize),
+		freeInodes: stat.Ffree,
+	}, nil
+}
+
+// readRSS returns the resident set size of the process in bytes
+func readRSS() (uint64, error) {
+	file, err := os.Open("/proc/self/status")
+	if err != nil {
+		return 0, fmt.Errorf("Unable to read the memory usage: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		// format: "VmRSS:	   12345 kB"
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 3 || fields[0] != "VmRSS:" {
+			continue
+		}
+		rssKB, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("Unable to parse the memory usage '%s': %w", fields[1], err)
+		}
+		return rssKB * 1024, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("Unable to read the memory usage: %w", err)
+	}
+	return 0, fmt.Errorf("Unable to find the memory usage (VmRSS) in /proc/self/status")
+}
+
+// readCgroupMemoryLimit returns the memory limit of the cgroup (v2 or v1) the process runs in
+func readCgroupMemoryLimit() (uint64, error) {
+	if limit, err := os.ReadFile(cgroupV2MemoryLimitFile); err == nil {
+		value := strings.TrimSpace(string(limit))
+		if value == "max" {
+			return 0, fmt.Errorf("No cgroup memory limit set")
+		}
+		return strconv.ParseUint(value, 10, 64)
+	}
+
+	limit, err := os.ReadFile(cgroupV1MemoryLimitFile)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to read the cgroup memory limit: %w", err)
+	}
+	value, err := strconv.ParseUint(strings.TrimSpace(string(limit)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Unable to parse the cgroup memory limit: %w", err)
+	}
+	if value >= cgroupV1Unlimited {
+		return 0, fmt.Errorf("No cgroup memory limit set")
+	}
+	return value, nil
+}
+
+// readFileDescriptorUsage returns the number of open file descriptors and the according limit (RLIMIT_NOFILE)
+func readFileDescriptorUsage() (uint64, uint64, error) {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return 0, 0, fmt.Errorf("Unable to read the open file descriptors: %w", err)
+	}
+
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return 0, 0, fmt.Errorf("Unable to read the file descriptor limit: %w", err)
+	}
+
+	// the directory itself is opened while reading it, hence it is not counted
+	return uint64(len(entries) - 1), limit.Cur, nil
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package checks
+
+import "fmt"
+
+var errNotSupported = fmt.Errorf("Not supported on this platform (only linux)")
+
+func readDiskUsage(path string) (diskUsage, error) {
+	return diskUsage{}, errNotSupported
+}
+
+func readRSS() (uint64, error) {
+	return 0, errNotSupported
+}
+
+func readCgroupMemoryLimit() (uint64, error) {
+	return 0, errNotSupported
+}
+
+func readFileDescriptorUsage() (uint64, uint64, error) {
+	return 0, 0, errNotSupported
+}
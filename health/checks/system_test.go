@@ -0,0 +1,157 @@
+//go:build linux
+
+package checks
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DiskSpaceCheck(t *testing.T) {
+
+	// GIVEN
+	dir := t.TempDir()
+	healthy, err := NewDiskSpaceCheck("disk", dir, 1)
+	require.NoError(t, err)
+	unhealthy, err := NewDiskSpaceCheck("disk", dir, math.MaxUint64)
+	require.NoError(t, err)
+	missing, err := NewDiskSpaceCheck("disk", filepath.Join(dir, "missing"), 1)
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.Error(t, unhealthy.IsHealthy())
+	assert.Error(t, missing.IsHealthy())
+}
+
+func Test_InodeCheck(t *testing.T) {
+
+	// GIVEN
+	dir := t.TempDir()
+	healthy, err := NewInodeCheck("inodes", dir, 0)
+	require.NoError(t, err)
+	unhealthy, err := NewInodeCheck("inodes", dir, math.MaxUint64)
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.Error(t, unhealthy.IsHealthy())
+}
+
+func Test_MemoryCheck(t *testing.T) {
+
+	// GIVEN
+	healthy, err := NewMemoryCheck("memory", 1<<50, 0.9)
+	require.NoError(t, err)
+	unhealthy, err := NewMemoryCheck("memory", 1024, 0.9)
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.Error(t, unhealthy.IsHealthy())
+
+	// WHEN
+	check, err := NewMemoryCheck("memory", 1024, 1.5)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+}
+
+func Test_ReadCgroupMemoryLimit(t *testing.T) {
+
+	// GIVEN
+	dir := t.TempDir()
+	v2File := filepath.Join(dir, "memory.max")
+	v1File := filepath.Join(dir, "memory.limit_in_bytes")
+	defer func(v2, v1 string) {
+		cgroupV2MemoryLimitFile = v2
+		cgroupV1MemoryLimitFile = v1
+	}(cgroupV2MemoryLimitFile, cgroupV1MemoryLimitFile)
+	cgroupV2MemoryLimitFile = v2File
+	cgroupV1MemoryLimitFile = v1File
+
+	// WHEN - no cgroup at all
+	_, err := readCgroupMemoryLimit()
+
+	// THEN
+	assert.Error(t, err)
+
+	// WHEN - cgroup v1 without limit
+	require.NoError(t, os.WriteFile(v1File, []byte("9223372036854771712\n"), 0644))
+	_, err = readCgroupMemoryLimit()
+
+	// THEN
+	assert.Error(t, err)
+
+	// WHEN - cgroup v1
+	require.NoError(t, os.WriteFile(v1File, []byte("536870912\n"), 0644))
+	limit, err := readCgroupMemoryLimit()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(536870912), limit)
+
+	// WHEN - cgroup v2 without limit
+	require.NoError(t, os.WriteFile(v2File, []byte("max\n"), 0644))
+	_, err = readCgroupMemoryLimit()
+
+	// THEN
+	assert.Error(t, err)
+
+	// WHEN - cgroup v2
+	require.NoError(t, os.WriteFile(v2File, []byte("1073741824\n"), 0644))
+	limit, err = readCgroupMemoryLimit()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1073741824), limit)
+
+	// WHEN
+	check, err := NewMemoryCheck("memory", 0, 0.9)
+
+	// THEN
+	assert.NoError(t, err)
+	assert.NoError(t, check.IsHealthy())
+}
+
+func Test_GoroutineCheck(t *testing.T) {
+
+	// GIVEN
+	healthy, err := NewGoroutineCheck("goroutines", 100000)
+	require.NoError(t, err)
+	unhealthy, err := NewGoroutineCheck("goroutines", 1)
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.Error(t, unhealthy.IsHealthy())
+
+	// WHEN
+	check, err := NewGoroutineCheck("goroutines", 0)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+}
+
+func Test_FileDescriptorCheck(t *testing.T) {
+
+	// GIVEN
+	open, limit, err := readFileDescriptorUsage()
+	require.NoError(t, err)
+	require.Greater(t, limit, open)
+	healthy, err := NewFileDescriptorCheck("fds", 1)
+	require.NoError(t, err)
+	unhealthy, err := NewFileDescriptorCheck("fds", 1/float64(limit))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.Error(t, unhealthy.IsHealthy())
+}
@@ -0,0 +1,62 @@
+// Package checks contains ready-made health checks that can be registered at a health.Monitor.
+package checks
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+// NewTCPDialCheck creates a Check that is healthy as long as a TCP connection to the given address (host:port)
+// can be established within the given timeout.
+func NewTCPDialCheck(name, address string, timeout time.Duration) (health.Check, error) {
+	if err := validate(name, timeout); err != nil {
+		return nil, err
+	}
+
+	if _, _, err := net.SplitHostPort(address); err != nil {
+		return nil, fmt.Errorf("Can't create a Check for the invalid address '%s': %w", address, err)
+	}
+
+	return tcpDialCheck{
+		name:    name,
+		address: address,
+		timeout: timeout,
+	}, nil
+}
+
+type tcpDialCheck struct {
+	name    string
+	address string
+	timeout time.Duration
+}
+
+func (c tcpDialCheck) IsHealthy() error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return fmt.Errorf("Unable to connect to '%s': %w", c.address, err)
+	}
+	return conn.Close()
+}
+
+func (c tcpDialCheck) String() string {
+	return c.name
+}
+
+func validate(name string, timeout time.Duration) error {
+	if len(strings.TrimSpace(name)) == 0 {
+		return fmt.Errorf("Can't create a Check with an empty name")
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("Can't create Check '%s' with a timeout of %s", name, timeout)
+	}
+	return nil
+}
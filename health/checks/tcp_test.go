@@ -0,0 +1,59 @@
+package checks
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TCPDialCheck(t *testing.T) {
+
+	// GIVEN
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	check, err := NewTCPDialCheck("tcp", address, time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, check)
+
+	// WHEN
+	err = check.IsHealthy()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "tcp", check.String())
+
+	// WHEN
+	listener.Close()
+	err = check.IsHealthy()
+
+	// THEN
+	assert.Error(t, err)
+}
+
+func Test_NewTCPDialCheckShouldFail(t *testing.T) {
+
+	// WHEN
+	check, err := NewTCPDialCheck("tcp", "localhost", time.Second)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+
+	// WHEN
+	check, err = NewTCPDialCheck("", "localhost:80", time.Second)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+
+	// WHEN
+	check, err = NewTCPDialCheck("tcp", "localhost:80", 0)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+}
@@ -0,0 +1,37 @@
+package health
+
+import "time"
+
+// Clock is the source of time of the Monitor. It can be replaced using WithClock (e.g. by a fake clock in tests).
+// The timeouts of the checks are not affected by the Clock, they always refer to the wall clock.
+type Clock interface {
+	Now() time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a timer created by a Clock, it behaves like a time.Timer
+type Timer interface {
+	// C returns the channel the current time is sent to as soon as the timer expires
+	C() <-chan time.Time
+	Stop() bool
+	Reset(d time.Duration) bool
+}
+
+// realClock is the Clock based on the wall clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
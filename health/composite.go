@@ -0,0 +1,171 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ChildResult is the result of a Check that is part of another Check (e.g. of a composite check)
+type ChildResult struct {
+	Name   string
+	Status Status
+	// nil in case the child is healthy
+	Err error
+	// the results of the children of the child (if any)
+	Children []ChildResult
+}
+
+// CompositeError is returned by a composite check (AllOf, AnyOf, AtLeast) in case not enough of its children are healthy.
+// In case enough children are healthy, but some of them are degraded or unhealthy, it is returned marked as degraded (see Degraded).
+// It contains the results of all children.
+type CompositeError struct {
+	// the number of healthy children and the number of healthy children that is required
+	Healthy  int
+	Required int
+	Results  []ChildResult
+}
+
+func (e *CompositeError) Error() string {
+	var childErrs []string
+	for _, result := range e.Results {
+		if result.Err != nil {
+			childErrs = append(childErrs, fmt.Sprintf("%s: %s", result.Name, result.Err))
+		}
+	}
+	return fmt.Sprintf("%d of %d checks healthy (%d required): %s", e.Healthy, len(e.Results), e.Required, strings.Join(childErrs, "; "))
+}
+
+// Unwrap returns the errors of the unhealthy (and degraded) children
+func (e *CompositeError) Unwrap() []error {
+	var errs []error
+	for _, result := range e.Results {
+		if result.Err != nil {
+			errs = append(errs, result.Err)
+		}
+	}
+	return errs
+}
+
+// nestedCheck is implemented by checks that consist of other checks (or that need the context of the evaluation).
+// Instead of IsHealthy the Monitor calls evaluateNested to obtain the results of the children as well.
+type nestedCheck interface {
+	evaluateNested(ctx context.Context) ([]ChildResult, error)
+}
+
+// AllOf creates a Check that is healthy as long as all of the given checks are healthy
+func AllOf(name string, checks ...Check) (Check, error) {
+	return newCompositeCheck(name, len(checks), checks)
+}
+
+// AnyOf creates a Check that is healthy as long as at least one of the given checks is healthy
+func AnyOf(name string, checks ...Check) (Check, error) {
+	return newCompositeCheck(name, 1, checks)
+}
+
+// AtLeast creates a Check that is healthy as long as at least n of the given checks are healthy (quorum)
+func AtLeast(name string, n int, checks ...Check) (Check, error) {
+	return newCompositeCheck(name, n, checks)
+}
+
+func newCompositeCheck(name string, required int, checks []Check) (Check, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Can't create a Check with an empty name")
+	}
+
+	if len(checks) == 0 {
+		return nil, fmt.Errorf("Can't create Check '%s' without checks", name)
+	}
+
+	for _, check := range checks {
+		if check == nil {
+			return nil, fmt.Errorf("Can't create Check '%s' containing a check that is nil", name)
+		}
+	}
+
+	if required < 1 || required > len(checks) {
+		return nil, fmt.Errorf("Can't create Check '%s' requiring %d of %d checks to be healthy", name, required, len(checks))
+	}
+
+	return &compositeCheck{
+		name:     name,
+		required: required,
+		checks:   checks,
+	}, nil
+}
+
+type compositeCheck struct {
+	name     string
+	required int
+	checks   []Check
+}
+
+func (c *compositeCheck) IsHealthy() error {
+	_, err := c.evaluateNested(context.Background())
+	return err
+}
+
+func (c *compositeCheck) String() string {
+	return c.name
+}
+
+// evaluateNested evaluates all children concurrently
+func (c *compositeCheck) evaluateNested(ctx context.Context) ([]ChildResult, error) {
+	results := make([]ChildResult, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			results[i] = evaluateChild(ctx, check)
+		}(i, check)
+	}
+	wg.Wait()
+
+	// a degraded child still works, hence it counts as healthy
+	healthy := 0
+	for _, result := range results {
+		if result.Status != StatusUnhealthy {
+			healthy++
+		}
+	}
+
+	if healthy < c.required {
+		return results, &CompositeError{Healthy: healthy, Required: c.required, Results: results}
+	}
+
+	// the quorum is met, but the failures of the children must not go unnoticed
+	for _, result := range results {
+		if result.Status != StatusHealthy {
+			return results, Degraded(&CompositeError{Healthy: healthy, Required: c.required, Results: results})
+		}
+	}
+	return results, nil
+}
+
+func evaluateChild(ctx context.Context, check Check) ChildResult {
+	result := ChildResult{Name: check.String()}
+
+	// a panicking child results in a failed child instead of a crash of the whole process
+	func() {
+		defer recoverPanic(&result.Err)
+		if nested, ok := check.(nestedCheck); ok {
+			result.Children, result.Err = nested.evaluateNested(ctx)
+		} else {
+			result.Err = check.IsHealthy()
+		}
+	}()
+
+	switch {
+	case result.Err == nil:
+		result.Status = StatusHealthy
+	case IsDegraded(result.Err):
+		result.Status = StatusDegraded
+	default:
+		result.Status = StatusUnhealthy
+	}
+	return result
+}
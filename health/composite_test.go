@@ -0,0 +1,164 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCheck(t *testing.T, name string, err error) Check {
+	check, e := NewSimpleCheck(name, func() error { return err })
+	require.NoError(t, e)
+	return check
+}
+
+func Test_AllOf(t *testing.T) {
+
+	// GIVEN
+	errBroker := fmt.Errorf("connection refused")
+	healthy, err := AllOf("brokers", newTestCheck(t, "broker-1", nil), newTestCheck(t, "broker-2", nil))
+	require.NoError(t, err)
+	unhealthy, err := AllOf("brokers", newTestCheck(t, "broker-1", nil), newTestCheck(t, "broker-2", errBroker))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.Equal(t, "brokers", healthy.String())
+	assert.NoError(t, healthy.IsHealthy())
+
+	err = unhealthy.IsHealthy()
+	assert.EqualError(t, err, "1 of 2 checks healthy (2 required): broker-2: connection refused")
+	var compositeErr *CompositeError
+	require.True(t, errors.As(err, &compositeErr))
+	assert.Equal(t, 1, compositeErr.Healthy)
+	assert.Equal(t, 2, compositeErr.Required)
+	require.Len(t, compositeErr.Results, 2)
+	assert.Equal(t, ChildResult{Name: "broker-1", Status: StatusHealthy}, compositeErr.Results[0])
+	assert.Equal(t, ChildResult{Name: "broker-2", Status: StatusUnhealthy, Err: errBroker}, compositeErr.Results[1])
+	assert.Equal(t, []error{errBroker}, compositeErr.Unwrap())
+}
+
+func Test_AnyOf(t *testing.T) {
+
+	// GIVEN
+	errReplica := fmt.Errorf("connection refused")
+	healthy, err := AnyOf("replicas", newTestCheck(t, "replica-1", errReplica), newTestCheck(t, "replica-2", nil))
+	require.NoError(t, err)
+	unhealthy, err := AnyOf("replicas", newTestCheck(t, "replica-1", errReplica), newTestCheck(t, "replica-2", errReplica))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	err = healthy.IsHealthy()
+	assert.True(t, IsDegraded(err))
+	assert.EqualError(t, err, "1 of 2 checks healthy (1 required): replica-1: connection refused")
+	var compositeErr *CompositeError
+	require.True(t, errors.As(err, &compositeErr))
+	assert.Equal(t, 1, compositeErr.Healthy)
+	assert.False(t, IsDegraded(unhealthy.IsHealthy()))
+	assert.EqualError(t, unhealthy.IsHealthy(), "0 of 2 checks healthy (1 required): replica-1: connection refused; replica-2: connection refused")
+}
+
+func Test_AtLeast(t *testing.T) {
+
+	// GIVEN
+	errNode := fmt.Errorf("timeout")
+	healthy, err := AtLeast("cluster", 2,
+		newTestCheck(t, "node-1", nil),
+		newTestCheck(t, "node-2", nil),
+		newTestCheck(t, "node-3", nil),
+	)
+	require.NoError(t, err)
+	quorum, err := AtLeast("cluster", 2,
+		newTestCheck(t, "node-1", nil),
+		newTestCheck(t, "node-2", errNode),
+		newTestCheck(t, "node-3", nil),
+	)
+	require.NoError(t, err)
+	noQuorum, err := AtLeast("cluster", 2,
+		newTestCheck(t, "node-1", nil),
+		newTestCheck(t, "node-2", errNode),
+		newTestCheck(t, "node-3", errNode),
+	)
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	err = quorum.IsHealthy()
+	assert.True(t, IsDegraded(err))
+	assert.EqualError(t, err, "2 of 3 checks healthy (2 required): node-2: timeout")
+	assert.EqualError(t, noQuorum.IsHealthy(), "1 of 3 checks healthy (2 required): node-2: timeout; node-3: timeout")
+}
+
+func Test_CompositeCheckShouldNotBeCreated(t *testing.T) {
+
+	// GIVEN
+	check := newTestCheck(t, "node-1", nil)
+
+	// WHEN + THEN
+	_, err := AllOf("", check)
+	assert.Error(t, err)
+	_, err = AllOf("cluster")
+	assert.Error(t, err)
+	_, err = AnyOf("cluster", check, nil)
+	assert.Error(t, err)
+	_, err = AtLeast("cluster", 0, check)
+	assert.Error(t, err)
+	_, err = AtLeast("cluster", 2, check)
+	assert.Error(t, err)
+}
+
+func Test_EvaluateChecksShouldContainNestedResults(t *testing.T) {
+
+	// GIVEN
+	errNode := fmt.Errorf("timeout")
+	nodes, err := AnyOf("nodes", newTestCheck(t, "node-1", errNode), newTestCheck(t, "node-2", nil))
+	require.NoError(t, err)
+	cluster, err := AllOf("cluster", nodes, newTestCheck(t, "config", nil))
+	require.NoError(t, err)
+
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(cluster))
+
+	// WHEN
+	now := time.Now()
+	cer := monitor.evaluateChecks(now)
+	_, response := checkEvaluationResultToResponse(cer, now, time.Second*30)
+
+	// THEN
+	// the failing node degrades its composite and the composite degrades the cluster
+	assert.Equal(t, uint(0), cer.numErrors)
+	require.Len(t, response.Checks, 1)
+	assert.Equal(t, "cluster", response.Checks[0].Name)
+	assert.Equal(t, "degraded", response.Checks[0].Status)
+	assert.Equal(t, "2 of 2 checks healthy (2 required): nodes: 1 of 2 checks healthy (1 required): node-1: timeout", response.Checks[0].Error)
+	assert.Equal(t, []childCheck{
+		{Name: "nodes", Status: "degraded", Error: "1 of 2 checks healthy (1 required): node-1: timeout", Checks: []childCheck{
+			{Name: "node-1", Status: "unhealthy", Error: "timeout"},
+			{Name: "node-2", Status: "healthy"},
+		}},
+		{Name: "config", Status: "healthy"},
+	}, response.Checks[0].Checks)
+}
+
+func Test_CompositeCheckShouldRecoverPanickingChild(t *testing.T) {
+
+	// GIVEN
+	panicking, err := NewSimpleCheck("panicking", func() error { panic("boom") })
+	require.NoError(t, err)
+	composite, err := AnyOf("composite", panicking, newTestCheck(t, "healthy", nil))
+	require.NoError(t, err)
+
+	// WHEN
+	results, err := composite.(nestedCheck).evaluateNested(context.Background())
+
+	// THEN
+	assert.True(t, IsDegraded(err))
+	require.Len(t, results, 2)
+	assert.EqualError(t, results[0].Err, "panic: boom")
+	assert.Equal(t, StatusUnhealthy, results[0].Status)
+}
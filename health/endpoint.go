@@ -2,6 +2,7 @@ package health
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"time"
 )
@@ -10,46 +11,87 @@ type response struct {
 	At     time.Time `json:"at,omitempty"`
 	Status string    `json:"status,omitempty"`
 	Checks []check   `json:"checks"`
+
+	// only set in case the service is in maintenance
+	Maintenance *maintenanceResponse `json:"maintenance,omitempty"`
 }
 
 type check struct {
-	Name   string `json:"name,omitempty"`
-	Status string `json:"status,omitempty"`
-	Error  string `json:"error,omitempty"`
+	Name            string    `json:"name,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	Severity        string    `json:"severity,omitempty"`
+	Error           string    `json:"error,omitempty"`
+	LastEvaluatedAt time.Time `json:"last_evaluated_at,omitempty"`
+	DurationMS      float64   `json:"duration_ms"`
+	Message         string    `json:"message,omitempty"`
+
+	NextEvaluationAt time.Time `json:"next_evaluation_at"`
+	// only set in case the check is backing off
+	BackoffMS float64 `json:"backoff_ms,omitempty"`
+
+	ConsecutiveFailures  uint `json:"consecutive_failures"`
+	ConsecutiveSuccesses uint `json:"consecutive_successes"`
+
+	Details map[string]interface{} `json:"details,omitempty"`
+	Checks  []childCheck           `json:"checks,omitempty"`
+}
+
+// childCheck is the result of a check that is part of another check
+type childCheck struct {
+	Name   string       `json:"name,omitempty"`
+	Status string       `json:"status,omitempty"`
+	Error  string       `json:"error,omitempty"`
+	Checks []childCheck `json:"checks,omitempty"`
 }
 
 func checkEvaluationResultToResponse(cer checkEvaluationResult, now time.Time, checkEvaluationTimeout time.Duration) (int, response) {
 
+	status := overallStatus(cer, now, checkEvaluationTimeout)
+
 	response := response{
 		At:     cer.at,
-		Status: "healthy",
+		Status: string(status),
 	}
 
+	// only a failing critical check results in an unavailable service,
+	// a degraded service is still able to handle requests
 	httpStatusCode := http.StatusOK
-
-	// switch to unhealthy in case there is at least one error
-	// or the last evaluation was too long ago
-	if cer.numErrors > 0 || now.Sub(cer.at) >= checkEvaluationTimeout {
+	if status == StatusUnhealthy {
 		httpStatusCode = http.StatusServiceUnavailable
-		response.Status = "unhealthy"
 	}
 
 	// add the result from the checks
 	var checks []check
 
-	for checkName, err := range cer.checkHealthyness {
+	for checkName, cr := range cer.checkResults {
 
-		status := "healthy"
+		status := StatusHealthy
 		errMsg := ""
-		if err != nil {
-			status = "unhealthy"
-			errMsg = err.Error()
+		if cr.err != nil {
+			status = StatusUnhealthy
+			errMsg = cr.err.Error()
+		} else if cr.degraded {
+			status = StatusDegraded
+			errMsg = errorToString(cr.degradedErr)
 		}
 
 		checks = append(checks, check{
-			Name:   checkName,
-			Status: status,
-			Error:  errMsg,
+			Name:            checkName,
+			Status:          string(status),
+			Severity:        cr.severity.String(),
+			Error:           errMsg,
+			LastEvaluatedAt: cr.evaluatedAt,
+			DurationMS:      durationToMS(cr.duration),
+			Message:         cr.message,
+
+			NextEvaluationAt: cr.nextEvaluationAt,
+			BackoffMS:        durationToMS(cr.backoffDelay),
+
+			ConsecutiveFailures:  cr.consecutiveFailures,
+			ConsecutiveSuccesses: cr.consecutiveSuccesses,
+
+			Details: cr.details,
+			Checks:  childResultsToChecks(cr.children),
 		})
 	}
 
@@ -57,19 +99,136 @@ func checkEvaluationResultToResponse(cer checkEvaluationResult, now time.Time, c
 	return httpStatusCode, response
 }
 
-// Health is the health endpoint
+func childResultsToChecks(results []ChildResult) []childCheck {
+	var checks []childCheck
+	for _, result := range results {
+		checks = append(checks, childCheck{
+			Name:   result.Name,
+			Status: string(result.Status),
+			Error:  errorToString(result.Err),
+			Checks: childResultsToChecks(result.Children),
+		})
+	}
+	return checks
+}
+
+// overallStatus returns the status of the given result at the given point in time
+func overallStatus(cer checkEvaluationResult, now time.Time, checkEvaluationTimeout time.Duration) Status {
+	// switch to unhealthy in case the last evaluation was too long ago
+	if now.Sub(cer.at) >= checkEvaluationTimeout {
+		return StatusUnhealthy
+	}
+	return cer.status()
+}
+
+func startupResultToResponse(cer checkEvaluationResult, now time.Time, checkEvaluationTimeout time.Duration) (int, response) {
+	_, response := checkEvaluationResultToResponse(cer, now, checkEvaluationTimeout)
+
+	// the startup probe stays in state starting until each of its checks has passed once,
+	// afterwards it is not evaluated any more (this is done by the liveness probe)
+	if !cer.startupCompleted {
+		return http.StatusServiceUnavailable, withStatus(response, StatusStarting)
+	}
+	return http.StatusOK, withStatus(response, StatusHealthy)
+}
+
+func withStatus(r response, status Status) response {
+	r.Status = string(status)
+	return r
+}
+
+// Status returns the overall status of the service based on the latest evaluation of the checks.
+// The status is StatusUnhealthy in case the latest evaluation is older than the evaluation timeout (e.g. the Monitor hangs).
+func (m *Monitor) Status() Status {
+	latestResult := m.latestCheckResult.Load().(checkEvaluationResult)
+	return overallStatus(latestResult, m.clock.Now(), m.checkEvaluationTimeout)
+}
+
+// ProbeStatus returns the status of the given probe based on the latest evaluation of the checks assigned to it
+// (i.e. the status the endpoint of the probe reports). The Startup probe reports StatusStarting until it has completed.
+func (m *Monitor) ProbeStatus(probe Probe) Status {
+	latestResult := m.latestCheckResult.Load().(checkEvaluationResult).forProbe(probe)
+	if probe == Startup {
+		if !latestResult.startupCompleted {
+			return StatusStarting
+		}
+		return StatusHealthy
+	}
+	return overallStatus(latestResult, m.clock.Now(), m.checkEvaluationTimeout)
+}
+
+// Evaluated returns true as soon as the checks have been evaluated at least once.
+// Before the first evaluation Status reports the status of a Monitor without any results.
+func (m *Monitor) Evaluated() bool {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+	return len(m.latestStatus) > 0
+}
+
+// Health is the health endpoint.
+// It reports the result of all registered checks regardless of the probes they are assigned to.
 func (m *Monitor) Health(w http.ResponseWriter, r *http.Request) {
 	m.logger.Debug().Msg("Health endpoint called")
 
 	latestResult := m.latestCheckResult.Load().(checkEvaluationResult)
-	code, response := checkEvaluationResultToResponse(latestResult, time.Now(), m.checkEvaluationTimeout)
+	now := m.clock.Now()
+	code, response := checkEvaluationResultToResponse(latestResult, now, m.checkEvaluationTimeout)
+	// the maintenance is reported, but it does not affect the overall status
+	response.Maintenance = maintenanceToResponse(m.activeMaintenance(now))
+	writeResponse(w, code, m.redact(r, response))
+}
 
-	w.Header().Add("Content-Type", "application/json")
-	w.WriteHeader(code)
+// Liveness is the endpoint for the liveness probe.
+// It reports only the result of the checks that are assigned to the Liveness probe.
+func (m *Monitor) Liveness(w http.ResponseWriter, r *http.Request) {
+	m.logger.Debug().Msg("Liveness endpoint called")
 
-	enc := json.NewEncoder(w)
-	if err := enc.Encode(response); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	latestResult := m.latestCheckResult.Load().(checkEvaluationResult)
+	code, response := checkEvaluationResultToResponse(latestResult.forProbe(Liveness), m.clock.Now(), m.checkEvaluationTimeout)
+	writeResponse(w, code, m.redact(r, response))
+}
+
+// Readiness is the endpoint for the readiness probe.
+// It reports only the result of the checks that are assigned to the Readiness probe.
+func (m *Monitor) Readiness(w http.ResponseWriter, r *http.Request) {
+	m.logger.Debug().Msg("Readiness endpoint called")
+
+	latestResult := m.latestCheckResult.Load().(checkEvaluationResult)
+	now := m.clock.Now()
+	code, response := checkEvaluationResultToResponse(latestResult.forProbe(Readiness), now, m.checkEvaluationTimeout)
+	// a service in maintenance is not ready, regardless of the results of its checks
+	if maintenance := m.activeMaintenance(now); maintenance != nil {
+		code = http.StatusServiceUnavailable
+		response = withStatus(response, StatusMaintenance)
+		response.Maintenance = maintenanceToResponse(maintenance)
+	}
+	writeResponse(w, code, m.redact(r, response))
+}
+
+// Startup is the endpoint for the startup probe.
+// It reports the status "starting" until each check that is assigned to the Startup probe has passed at least once.
+func (m *Monitor) Startup(w http.ResponseWriter, r *http.Request) {
+	m.logger.Debug().Msg("Startup endpoint called")
+
+	latestResult := m.latestCheckResult.Load().(checkEvaluationResult)
+	code, response := startupResultToResponse(latestResult.forProbe(Startup), m.clock.Now(), m.checkEvaluationTimeout)
+	writeResponse(w, code, m.redact(r, response))
+}
+
+func writeResponse(w http.ResponseWriter, code int, response response) {
+	writeJSON(w, code, response)
+}
+
+// writeJSON writes the given value as JSON with the given status code.
+// The value is encoded before anything is written, hence a value that can't be encoded results in a clean 500.
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Unable to encode the response: %s", err), http.StatusInternalServerError)
 		return
 	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(append(data, '\n'))
 }
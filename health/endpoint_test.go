@@ -30,12 +30,12 @@ func Test_CheckEvaluationResultToResponse(t *testing.T) {
 	assert.Equal(t, at, response.At)
 
 	// GIVEN  multiple checks
-	healthyness := make(map[string]error)
-	healthyness["check1"] = fmt.Errorf("No connection")
-	healthyness["check2"] = fmt.Errorf("Timeout")
-	healthyness["check3"] = nil
+	healthyness := make(map[string]checkResult)
+	healthyness["check1"] = checkResult{err: fmt.Errorf("No connection")}
+	healthyness["check2"] = checkResult{err: fmt.Errorf("Timeout")}
+	healthyness["check3"] = checkResult{}
 
-	cer = checkEvaluationResult{at: at, checkHealthyness: healthyness, numErrors: 2}
+	cer = checkEvaluationResult{at: at, checkResults: healthyness, numErrors: 2}
 
 	// WHEN
 	status, response = checkEvaluationResultToResponse(cer, _29SecAfter, timeout)
@@ -72,6 +72,45 @@ func Test_CheckEvaluationResultToResponseShouldBeUnhealthyIfTooOld(t *testing.T)
 	assert.Equal(t, at, response.At)
 }
 
+func Test_CheckEvaluationResultToResponseShouldBeDegraded(t *testing.T) {
+
+	// GIVEN
+	at := time.Now()
+	timeout := time.Second * 30
+	results := make(map[string]checkResult)
+	results["db"] = checkResult{severity: Critical}
+	results["cache"] = checkResult{err: fmt.Errorf("No connection"), severity: NonCritical}
+	cer := checkEvaluationResult{at: at, checkResults: results, numErrors: 1}
+
+	// WHEN
+	status, response := checkEvaluationResultToResponse(cer, at, timeout)
+
+	// THEN
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "degraded", response.Status)
+	assert.Len(t, response.Checks, 2)
+
+	checkByName := make(map[string]check)
+	checkByName[response.Checks[0].Name] = response.Checks[0]
+	checkByName[response.Checks[1].Name] = response.Checks[1]
+	assert.Equal(t, "unhealthy", checkByName["cache"].Status)
+	assert.Equal(t, "non-critical", checkByName["cache"].Severity)
+	assert.Equal(t, "No connection", checkByName["cache"].Error)
+	assert.Equal(t, "healthy", checkByName["db"].Status)
+	assert.Equal(t, "critical", checkByName["db"].Severity)
+
+	// GIVEN
+	results["db"] = checkResult{err: fmt.Errorf("Timeout"), severity: Critical}
+	cer = checkEvaluationResult{at: at, checkResults: results, numErrors: 2}
+
+	// WHEN
+	status, response = checkEvaluationResultToResponse(cer, at, timeout)
+
+	// THEN
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "unhealthy", response.Status)
+}
+
 func Test_HealthEndpoint(t *testing.T) {
 
 	// GIVEN
@@ -80,13 +119,13 @@ func Test_HealthEndpoint(t *testing.T) {
 	require.NotNil(t, monitor)
 	req := httptest.NewRequest("GET", "http://example.com/foo", nil)
 	w := httptest.NewRecorder()
-	healthyness := make(map[string]error)
-	healthyness["check1"] = nil
-	healthyness["check2"] = fmt.Errorf("Timeout")
+	healthyness := make(map[string]checkResult)
+	healthyness["check1"] = checkResult{consecutiveSuccesses: 4}
+	healthyness["check2"] = checkResult{err: fmt.Errorf("Timeout"), consecutiveFailures: 3}
 	monitor.latestCheckResult.Store(checkEvaluationResult{
-		at:               time.Now(),
-		numErrors:        2,
-		checkHealthyness: healthyness,
+		at:           time.Now(),
+		numErrors:    2,
+		checkResults: healthyness,
 	})
 
 	// WHEN
@@ -112,4 +151,160 @@ func Test_HealthEndpoint(t *testing.T) {
 	assert.Empty(t, checkByName["check1"].Error)
 	assert.Equal(t, "unhealthy", checkByName["check2"].Status)
 	assert.Equal(t, "Timeout", checkByName["check2"].Error)
+	assert.Equal(t, uint(4), checkByName["check1"].ConsecutiveSuccesses)
+	assert.Equal(t, uint(0), checkByName["check1"].ConsecutiveFailures)
+	assert.Equal(t, uint(3), checkByName["check2"].ConsecutiveFailures)
+}
+
+func Test_ProbeEndpoints(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	results := make(map[string]checkResult)
+	results["liveness"] = checkResult{probes: Liveness}
+	results["readiness"] = checkResult{err: fmt.Errorf("No connection"), probes: Readiness}
+	results["startup"] = checkResult{probes: Startup}
+	monitor.latestCheckResult.Store(checkEvaluationResult{
+		at:               time.Now(),
+		numErrors:        1,
+		checkResults:     results,
+		startupCompleted: true,
+	})
+
+	tests := []struct {
+		name           string
+		handler        http.HandlerFunc
+		expectedCode   int
+		expectedStatus string
+		expectedCheck  string
+	}{
+		{name: "liveness", handler: monitor.Liveness, expectedCode: http.StatusOK, expectedStatus: "healthy", expectedCheck: "liveness"},
+		{name: "readiness", handler: monitor.Readiness, expectedCode: http.StatusServiceUnavailable, expectedStatus: "unhealthy", expectedCheck: "readiness"},
+		{name: "startup", handler: monitor.Startup, expectedCode: http.StatusOK, expectedStatus: "healthy", expectedCheck: "startup"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "http://example.com/foo", nil)
+			w := httptest.NewRecorder()
+
+			// WHEN
+			test.handler(w, req)
+
+			// THEN
+			resp := w.Result()
+			defer resp.Body.Close()
+			assert.Equal(t, test.expectedCode, resp.StatusCode)
+
+			respHealth := response{}
+			err := json.NewDecoder(resp.Body).Decode(&respHealth)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedStatus, respHealth.Status)
+			require.Len(t, respHealth.Checks, 1)
+			assert.Equal(t, test.expectedCheck, respHealth.Checks[0].Name)
+		})
+	}
+}
+
+func Test_StartupResultToResponse(t *testing.T) {
+
+	// GIVEN
+	at := time.Now()
+	timeout := time.Second * 30
+	results := make(map[string]checkResult)
+	results["check1"] = checkResult{err: fmt.Errorf("Not yet"), probes: Startup}
+	cer := checkEvaluationResult{at: at, checkResults: results, numErrors: 1}
+
+	// WHEN
+	status, response := startupResultToResponse(cer, at, timeout)
+
+	// THEN
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "starting", response.Status)
+	assert.Len(t, response.Checks, 1)
+
+	// GIVEN
+	cer.startupCompleted = true
+
+	// WHEN
+	status, response = startupResultToResponse(cer, at, timeout)
+
+	// THEN
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "healthy", response.Status)
+}
+
+func Test_CheckEvaluationResultToResponseShouldContainDetails(t *testing.T) {
+
+	// GIVEN
+	at := time.Now()
+	results := make(map[string]checkResult)
+	results["db"] = checkResult{details: map[string]interface{}{"open_connections": 3}}
+	cer := checkEvaluationResult{at: at, checkResults: results}
+
+	// WHEN
+	_, response := checkEvaluationResultToResponse(cer, at, time.Second*30)
+
+	// THEN
+	require.Len(t, response.Checks, 1)
+	assert.Equal(t, 3, response.Checks[0].Details["open_connections"])
+}
+
+func Test_Status(t *testing.T) {
+
+	// GIVEN
+	failing, err := NewSimpleCheck("failing", func() error { return fmt.Errorf("failed") })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(failing, WithSeverity(NonCritical)))
+	assert.False(t, monitor.Evaluated())
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	assert.True(t, monitor.Evaluated())
+	assert.Equal(t, StatusDegraded, monitor.Status())
+
+	// WHEN
+	monitor.latestCheckResult.Store(checkEvaluationResult{at: time.Now().Add(-time.Minute)})
+
+	// THEN
+	assert.Equal(t, StatusUnhealthy, monitor.Status())
+}
+
+func Test_WriteJSONShouldFailCleanly(t *testing.T) {
+
+	// GIVEN
+	recorder := httptest.NewRecorder()
+
+	// WHEN
+	writeJSON(recorder, http.StatusOK, map[string]interface{}{"bad": make(chan int)})
+
+	// THEN
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "Unable to encode the response")
+}
+
+func Test_ProbeStatus(t *testing.T) {
+
+	// GIVEN
+	failing, err := NewSimpleCheck("db", func() error { return fmt.Errorf("failed") })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(failing, ForProbes(Readiness, Startup)))
+	assert.Equal(t, StatusStarting, monitor.ProbeStatus(Startup))
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	assert.Equal(t, StatusUnhealthy, monitor.Status())
+	assert.Equal(t, StatusUnhealthy, monitor.ProbeStatus(Readiness))
+	assert.Equal(t, StatusHealthy, monitor.ProbeStatus(Liveness))
+	assert.Equal(t, StatusStarting, monitor.ProbeStatus(Startup))
 }
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"sync"
+	"time"
+)
+
+// subscriberBufferSize is the number of events that are buffered per subscriber
+const subscriberBufferSize = 32
+
+// EventType specifies what has changed
+type EventType int
+
+const (
+	// CheckStatusChanged is emitted when the status of a Check has changed (after applying its thresholds)
+	CheckStatusChanged EventType = iota
+	// StatusChanged is emitted when the overall status of the service has changed
+	StatusChanged
+)
+
+func (e EventType) String() string {
+	if e == StatusChanged {
+		return "status-changed"
+	}
+	return "check-status-changed"
+}
+
+// Event is emitted by the Monitor on each change of the status of a Check or of the overall status.
+// The first evaluation results in an Event without a From status.
+type Event struct {
+	Type EventType
+	At   time.Time
+	// the name of the Check whose status has changed, empty for StatusChanged
+	Check string
+	From  Status
+	To    Status
+	// the error of the Check that caused the change, nil for StatusChanged
+	Err error
+}
+
+// EventFun is called for each Event a subscriber receives
+type EventFun func(event Event)
+
+// UnsubscribeFun cancels a subscription
+type UnsubscribeFun func()
+
+type subscriber struct {
+	events chan Event
+	done   chan struct{}
+}
+
+// Subscribe registers the given function which is called for each change of the status of a Check or of the overall status.
+// The function is called sequentially on a separate go-routine, hence a slow subscriber does not block the Monitor.
+// In case a subscriber is too slow to keep up the events it can't take are dropped.
+func (m *Monitor) Subscribe(fun EventFun) UnsubscribeFun {
+	sub := &subscriber{
+		events: make(chan Event, subscriberBufferSize),
+		done:   make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-sub.done:
+				return
+			case event := <-sub.events:
+				fun(event)
+			}
+		}
+	}()
+
+	m.subscribersMux.Lock()
+	defer m.subscribersMux.Unlock()
+	m.subscribers[sub] = struct{}{}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			m.subscribersMux.Lock()
+			defer m.subscribersMux.Unlock()
+			delete(m.subscribers, sub)
+			close(sub.done)
+		})
+	}
+}
+
+// SubscribeChan registers the given channel which receives each change of the status of a Check or of the overall status.
+// The channel is not closed when the subscription is cancelled.
+func (m *Monitor) SubscribeChan(events chan<- Event) UnsubscribeFun {
+	done := make(chan struct{})
+	unsubscribe := m.Subscribe(func(event Event) {
+		select {
+		case events <- event:
+		case <-done:
+		}
+	})
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			unsubscribe()
+		})
+	}
+}
+
+// publish hands the given events over to all subscribers without blocking
+func (m *Monitor) publish(events []Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	m.subscribersMux.RLock()
+	defer m.subscribersMux.RUnlock()
+
+	for sub := range m.subscribers {
+		for _, event := range events {
+			select {
+			case sub.events <- event:
+			default:
+				m.logger.Warn().Str("event", event.Type.String()).Str("check", event.Check).Msg("Subscriber is too slow, dropping event")
+			}
+		}
+	}
+}
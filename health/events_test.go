@@ -0,0 +1,156 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMonitorWithSwitchableCheck(t *testing.T) (*Monitor, func(err error)) {
+	errChan := make(chan error, 1)
+	errChan <- nil
+	var checkErr error
+	check, err := NewSimpleCheck("db", func() error {
+		select {
+		case checkErr = <-errChan:
+		default:
+		}
+		return checkErr
+	})
+	require.NoError(t, err)
+
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	err = monitor.Register(check)
+	require.NoError(t, err)
+
+	return monitor, func(err error) {
+		errChan <- err
+	}
+}
+
+func receive(t *testing.T, events <-chan Event) Event {
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		require.FailNow(t, "no event received")
+	}
+	return Event{}
+}
+
+func Test_SubscribeChanShouldReceiveTransitionsOnly(t *testing.T) {
+
+	// GIVEN
+	monitor, setResult := newMonitorWithSwitchableCheck(t)
+	events := make(chan Event, 10)
+	unsubscribe := monitor.SubscribeChan(events)
+	defer unsubscribe()
+	errConnection := fmt.Errorf("no connection")
+	start := time.Now()
+
+	// WHEN
+	monitor.evaluateChecks(start)
+
+	// THEN
+	event := receive(t, events)
+	assert.Equal(t, CheckStatusChanged, event.Type)
+	assert.Equal(t, "db", event.Check)
+	assert.Equal(t, Status(""), event.From)
+	assert.Equal(t, StatusHealthy, event.To)
+	event = receive(t, events)
+	assert.Equal(t, StatusChanged, event.Type)
+	assert.Equal(t, StatusHealthy, event.To)
+
+	// WHEN - nothing changes
+	monitor.evaluateChecks(start.Add(monitor.checkInterval))
+
+	// WHEN
+	setResult(errConnection)
+	monitor.evaluateChecks(start.Add(monitor.checkInterval * 2))
+
+	// THEN
+	event = receive(t, events)
+	assert.Equal(t, CheckStatusChanged, event.Type)
+	assert.Equal(t, "db", event.Check)
+	assert.Equal(t, StatusHealthy, event.From)
+	assert.Equal(t, StatusUnhealthy, event.To)
+	assert.Equal(t, errConnection, event.Err)
+	assert.Equal(t, start.Add(monitor.checkInterval*2), event.At)
+	event = receive(t, events)
+	assert.Equal(t, StatusChanged, event.Type)
+	assert.Empty(t, event.Check)
+	assert.Equal(t, StatusHealthy, event.From)
+	assert.Equal(t, StatusUnhealthy, event.To)
+	assert.Len(t, events, 0)
+}
+
+func Test_UnsubscribeShouldStopDelivery(t *testing.T) {
+
+	// GIVEN
+	monitor, setResult := newMonitorWithSwitchableCheck(t)
+	events := make(chan Event, 10)
+	unsubscribeCallback := monitor.Subscribe(func(event Event) {
+		events <- event
+	})
+	start := time.Now()
+	monitor.evaluateChecks(start)
+	receive(t, events)
+	receive(t, events)
+
+	// WHEN
+	unsubscribeCallback()
+	unsubscribeCallback()
+	setResult(fmt.Errorf("no connection"))
+	monitor.evaluateChecks(start.Add(monitor.checkInterval))
+
+	// THEN
+	time.Sleep(time.Millisecond * 50)
+	assert.Len(t, events, 0)
+}
+
+func Test_SlowSubscriberShouldNotBlockTheMonitor(t *testing.T) {
+
+	// GIVEN
+	numEvaluations := 0
+	flappingCheck, err := NewSimpleCheck("flapping", func() error {
+		numEvaluations++
+		if numEvaluations%2 == 0 {
+			return fmt.Errorf("no connection")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	err = monitor.Register(flappingCheck)
+	require.NoError(t, err)
+	release := make(chan struct{})
+	defer close(release)
+	unsubscribe := monitor.Subscribe(func(event Event) {
+		<-release
+	})
+	defer unsubscribe()
+	start := time.Now()
+
+	// WHEN
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < subscriberBufferSize*2; i++ {
+			monitor.evaluateChecks(start.Add(monitor.checkInterval * time.Duration(i)))
+		}
+	}()
+
+	// THEN
+	select {
+	case <-done:
+	case <-time.After(time.Second * 5):
+		assert.Fail(t, "monitor was blocked by a slow subscriber")
+	}
+}
@@ -0,0 +1,123 @@
+// Package healthcheck provides a probe for images without curl or wget (e.g. distroless) that can be used as
+// Docker HEALTHCHECK or as exec probe. The probe is part of the service binary:
+//
+//	func main() {
+//		if len(os.Args) > 1 && os.Args[1] == "healthcheck" {
+//			os.Exit(healthcheck.Run(os.Args[2:], os.Stderr))
+//		}
+//		...
+//	}
+//
+//	HEALTHCHECK CMD ["/service", "healthcheck", "-url", "http://127.0.0.1:8080/health"]
+//
+// Alternatively the probe can read the status file written by a health.Monitor created with health.WithStatusFile:
+//
+//	HEALTHCHECK CMD ["/service", "healthcheck", "-file", "/tmp/health.json"]
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+const (
+	defaultURL     = "http://127.0.0.1:8080/health"
+	defaultTimeout = time.Second * 3
+	defaultMaxAge  = time.Second * 30
+)
+
+// status is the part of the response of the health endpoint (or of the status file) the probe is interested in
+type status struct {
+	At     time.Time `json:"at"`
+	Status string    `json:"status"`
+}
+
+// Run runs the probe with the given command line arguments and returns the exit code (0 healthy, 1 unhealthy).
+// The reason for an unhealthy result is written to out.
+//
+//	-url      the health endpoint that is requested (default http://127.0.0.1:8080/health)
+//	-timeout  the time the request may take at most (default 3s)
+//	-file     the status file that is read instead of requesting the endpoint
+//	-max-age  the age the status file may have at most (default 30s)
+func Run(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("healthcheck", flag.ContinueOnError)
+	flags.SetOutput(out)
+	url := flags.String("url", defaultURL, "the health endpoint that is requested")
+	timeout := flags.Duration("timeout", defaultTimeout, "the time the request may take at most")
+	file := flags.String("file", "", "the status file that is read instead of requesting the endpoint")
+	maxAge := flags.Duration("max-age", defaultMaxAge, "the age the status file may have at most")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+
+	var err error
+	if len(*file) > 0 {
+		err = CheckFile(*file, *maxAge, time.Now())
+	} else {
+		ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+		defer cancel()
+		err = CheckURL(ctx, *url)
+	}
+
+	if err != nil {
+		fmt.Fprintln(out, err)
+		return 1
+	}
+	return 0
+}
+
+// CheckURL requests the given health endpoint and returns an error in case the service is unhealthy.
+// A degraded service is regarded as healthy.
+func CheckURL(ctx context.Context, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("Unable to create request for '%s': %w", url, err)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("Unable to request '%s': %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	var s status
+	if err := json.NewDecoder(resp.Body).Decode(&s); err != nil {
+		return fmt.Errorf("Unable to parse response of '%s' (status code %d): %w", url, resp.StatusCode, err)
+	}
+	return evaluate(s)
+}
+
+// CheckFile reads the given status file and returns an error in case the service is unhealthy
+// or the file was not refreshed within maxAge (e.g. since the monitor hangs).
+func CheckFile(path string, maxAge time.Duration, now time.Time) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("Unable to read status file: %w", err)
+	}
+
+	var s status
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("Unable to parse status file '%s': %w", path, err)
+	}
+
+	if age := now.Sub(s.At); age > maxAge {
+		return fmt.Errorf("Status file '%s' is outdated, it was written %s ago", path, age)
+	}
+	return evaluate(s)
+}
+
+func evaluate(s status) error {
+	switch health.Status(s.Status) {
+	case health.StatusHealthy, health.StatusDegraded:
+		return nil
+	}
+	return fmt.Errorf("Service is %s", s.Status)
+}
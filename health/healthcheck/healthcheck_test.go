@@ -0,0 +1,92 @@
+package healthcheck
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMonitor(t *testing.T, checkErr error, options ...health.Option) *health.Monitor {
+	monitor, err := health.NewMonitor(options...)
+	require.NoError(t, err)
+	check, err := health.NewSimpleCheck("db", func() error { return checkErr })
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(check))
+	monitor.EvaluateNow()
+	return monitor
+}
+
+func Test_CheckURL(t *testing.T) {
+
+	// GIVEN
+	healthy := httptest.NewServer(http.HandlerFunc(newMonitor(t, nil).Health))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(newMonitor(t, fmt.Errorf("connection refused")).Health))
+	defer unhealthy.Close()
+	invalid := httptest.NewServer(http.NotFoundHandler())
+	defer invalid.Close()
+
+	// WHEN + THEN
+	assert.NoError(t, CheckURL(context.Background(), healthy.URL))
+	assert.EqualError(t, CheckURL(context.Background(), unhealthy.URL), "Service is unhealthy")
+	assert.Error(t, CheckURL(context.Background(), invalid.URL))
+	assert.Error(t, CheckURL(context.Background(), "http://127.0.0.1:1/health"))
+}
+
+func Test_CheckFile(t *testing.T) {
+
+	// GIVEN
+	dir := t.TempDir()
+	healthyFile := filepath.Join(dir, "healthy.json")
+	unhealthyFile := filepath.Join(dir, "unhealthy.json")
+	newMonitor(t, nil, health.WithStatusFile(healthyFile))
+	newMonitor(t, fmt.Errorf("connection refused"), health.WithStatusFile(unhealthyFile))
+	now := time.Now()
+
+	// WHEN + THEN
+	assert.NoError(t, CheckFile(healthyFile, time.Second*30, now))
+	assert.EqualError(t, CheckFile(unhealthyFile, time.Second*30, now), "Service is unhealthy")
+	assert.Error(t, CheckFile(healthyFile, time.Second*30, now.Add(time.Minute)))
+	assert.Error(t, CheckFile(filepath.Join(dir, "missing.json"), time.Second*30, now))
+}
+
+func Test_Run(t *testing.T) {
+
+	// GIVEN
+	healthy := httptest.NewServer(http.HandlerFunc(newMonitor(t, nil).Health))
+	defer healthy.Close()
+	file := filepath.Join(t.TempDir(), "health.json")
+	newMonitor(t, fmt.Errorf("connection refused"), health.WithStatusFile(file))
+	out := bytes.Buffer{}
+
+	// WHEN + THEN
+	assert.Equal(t, 0, Run([]string{"-url", healthy.URL, "-timeout", "1s"}, &out))
+	assert.Equal(t, 1, Run([]string{"-file", file}, &out))
+	assert.Contains(t, out.String(), "Service is unhealthy")
+	assert.Equal(t, 1, Run([]string{"-unknown"}, &out))
+}
+
+func Test_RunShouldFailWithoutStatusFile(t *testing.T) {
+
+	// GIVEN
+	out := bytes.Buffer{}
+	path := filepath.Join(t.TempDir(), "health.json")
+
+	// WHEN
+	code := Run([]string{"-file", path}, &out)
+
+	// THEN
+	assert.Equal(t, 1, code)
+	_, err := os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,52 @@
+package healthtest
+
+import (
+	"sync"
+)
+
+// ScriptedCheck is a health.Check that returns a predefined sequence of results.
+// Once the sequence is exhausted the check is healthy.
+type ScriptedCheck struct {
+	name        string
+	results     []error
+	evaluations int
+	mux         sync.Mutex
+}
+
+// NewScriptedCheck creates a ScriptedCheck that returns the given results in order (nil means healthy)
+func NewScriptedCheck(name string, results ...error) *ScriptedCheck {
+	return &ScriptedCheck{name: name, results: results}
+}
+
+// NewFailingCheck creates a ScriptedCheck that fails the given number of times with err and is healthy afterwards
+func NewFailingCheck(name string, failures int, err error) *ScriptedCheck {
+	results := make([]error, failures)
+	for i := range results {
+		results[i] = err
+	}
+	return NewScriptedCheck(name, results...)
+}
+
+// IsHealthy returns the next result of the sequence
+func (c *ScriptedCheck) IsHealthy() error {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	idx := c.evaluations
+	c.evaluations++
+	if idx >= len(c.results) {
+		return nil
+	}
+	return c.results[idx]
+}
+
+func (c *ScriptedCheck) String() string {
+	return c.name
+}
+
+// Evaluations returns how often the check was evaluated so far
+func (c *ScriptedCheck) Evaluations() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.evaluations
+}
@@ -0,0 +1,35 @@
+package healthtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ScriptedCheck(t *testing.T) {
+
+	// GIVEN
+	errFirst := fmt.Errorf("first")
+	check := NewScriptedCheck("db", errFirst, nil, errFirst)
+
+	// WHEN + THEN
+	assert.Equal(t, "db", check.String())
+	assert.Equal(t, errFirst, check.IsHealthy())
+	assert.NoError(t, check.IsHealthy())
+	assert.Equal(t, errFirst, check.IsHealthy())
+	assert.NoError(t, check.IsHealthy())
+	assert.Equal(t, 4, check.Evaluations())
+}
+
+func Test_FailingCheck(t *testing.T) {
+
+	// GIVEN
+	errDB := fmt.Errorf("connection refused")
+	check := NewFailingCheck("db", 2, errDB)
+
+	// WHEN + THEN
+	assert.Equal(t, errDB, check.IsHealthy())
+	assert.Equal(t, errDB, check.IsHealthy())
+	assert.NoError(t, check.IsHealthy())
+}
@@ -0,0 +1,107 @@
+// Package healthtest provides utilities for testing services that use the health package
+// without depending on the wall clock, e.g.
+//
+//	clock := healthtest.NewFakeClock(time.Now())
+//	monitor, _ := health.NewMonitor(health.WithClock(clock))
+//	monitor.Register(healthtest.NewFailingCheck("db", 2, fmt.Errorf("connection refused")))
+//
+//	monitor.EvaluateNow()
+//	healthtest.AssertResponse(t, monitor.Health, http.StatusServiceUnavailable, health.StatusUnhealthy)
+package healthtest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+// FakeClock is a health.Clock whose time only changes by calling Advance
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+	mux    sync.Mutex
+}
+
+// NewFakeClock creates a FakeClock that starts at the given point in time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the current time of the clock
+func (c *FakeClock) Now() time.Time {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.now
+}
+
+// NewTimer creates a timer that expires as soon as the clock was advanced by at least d
+func (c *FakeClock) NewTimer(d time.Duration) health.Timer {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	timer := &fakeTimer{clock: c, c: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	timer.reset(d)
+	return timer
+}
+
+// Advance moves the clock forward by d and fires all timers that expired
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.now = c.now.Add(d)
+	for _, timer := range c.timers {
+		timer.fireIfExpired()
+	}
+}
+
+// fakeTimer is a timer of a FakeClock, its state is guarded by the mutex of the clock
+type fakeTimer struct {
+	clock    *FakeClock
+	c        chan time.Time
+	deadline time.Time
+	active   bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+
+	wasActive := t.active
+	t.active = false
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mux.Lock()
+	defer t.clock.mux.Unlock()
+
+	wasActive := t.active
+	t.reset(d)
+	return wasActive
+}
+
+func (t *fakeTimer) reset(d time.Duration) {
+	t.deadline = t.clock.now.Add(d)
+	t.active = true
+	t.fireIfExpired()
+}
+
+func (t *fakeTimer) fireIfExpired() {
+	if !t.active || t.deadline.After(t.clock.now) {
+		return
+	}
+
+	t.active = false
+	select {
+	case t.c <- t.clock.now:
+	default:
+		// like a time.Timer the value is dropped in case the previous one was not consumed yet
+	}
+}
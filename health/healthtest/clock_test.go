@@ -0,0 +1,65 @@
+package healthtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FakeClock(t *testing.T) {
+
+	// GIVEN
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+	timer := clock.NewTimer(time.Second * 5)
+
+	// WHEN
+	clock.Advance(time.Second * 4)
+
+	// THEN
+	assert.Equal(t, start.Add(time.Second*4), clock.Now())
+	assert.Len(t, timer.C(), 0)
+
+	// WHEN
+	clock.Advance(time.Second)
+
+	// THEN
+	assert.Equal(t, start.Add(time.Second*5), <-timer.C())
+	assert.False(t, timer.Stop())
+}
+
+func Test_FakeClockTimerStopAndReset(t *testing.T) {
+
+	// GIVEN
+	clock := NewFakeClock(time.Now())
+	timer := clock.NewTimer(time.Second)
+
+	// WHEN
+	stopped := timer.Stop()
+	clock.Advance(time.Second)
+
+	// THEN
+	assert.True(t, stopped)
+	assert.Len(t, timer.C(), 0)
+
+	// WHEN
+	wasActive := timer.Reset(time.Second)
+	clock.Advance(time.Second)
+
+	// THEN
+	assert.False(t, wasActive)
+	assert.Len(t, timer.C(), 1)
+}
+
+func Test_FakeClockTimerShouldFireImmediately(t *testing.T) {
+
+	// GIVEN
+	clock := NewFakeClock(time.Now())
+
+	// WHEN
+	timer := clock.NewTimer(0)
+
+	// THEN
+	assert.Len(t, timer.C(), 1)
+}
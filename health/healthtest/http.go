@@ -0,0 +1,73 @@
+package healthtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+)
+
+// Response is the decoded body of a response of one of the endpoints of the health.Monitor
+type Response struct {
+	At     time.Time       `json:"at"`
+	Status string          `json:"status"`
+	Checks []CheckResponse `json:"checks"`
+}
+
+// CheckResponse is the result of one check within a Response
+type CheckResponse struct {
+	Name     string                 `json:"name"`
+	Status   string                 `json:"status"`
+	Severity string                 `json:"severity"`
+	Error    string                 `json:"error"`
+	Message  string                 `json:"message"`
+	Details  map[string]interface{} `json:"details"`
+	Checks   []CheckResponse        `json:"checks"`
+}
+
+// Check returns the result of the check with the given name, false in case the response does not contain it
+func (r Response) Check(name string) (CheckResponse, bool) {
+	for _, check := range r.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return CheckResponse{}, false
+}
+
+// Get calls the given handler (e.g. monitor.Readiness) and returns the status code and the decoded body
+func Get(t testing.TB, handler http.HandlerFunc) (int, Response) {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	handler(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	var response Response
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Unable to decode the response '%s': %s", recorder.Body.String(), err)
+	}
+	return recorder.Code, response
+}
+
+// AssertResponse calls the given handler and fails the test in case the status code or the status do not match
+func AssertResponse(t testing.TB, handler http.HandlerFunc, expectedCode int, expectedStatus health.Status) Response {
+	t.Helper()
+
+	code, response := Get(t, handler)
+	if code != expectedCode {
+		t.Errorf("Expected status code %d but got %d", expectedCode, code)
+	}
+	if response.Status != string(expectedStatus) {
+		t.Errorf("Expected status '%s' but got '%s'", expectedStatus, response.Status)
+	}
+	return response
+}
+
+// AdvanceAndEvaluate advances the clock by d and evaluates all checks of the monitor
+func AdvanceAndEvaluate(clock *FakeClock, monitor *health.Monitor, d time.Duration) {
+	clock.Advance(d)
+	monitor.EvaluateNow()
+}
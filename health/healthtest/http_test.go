@@ -0,0 +1,116 @@
+package healthtest
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MonitorWithFakeClock(t *testing.T) {
+
+	// GIVEN
+	clock := NewFakeClock(time.Now())
+	monitor, err := health.NewMonitor(health.WithClock(clock))
+	require.NoError(t, err)
+	db := NewFailingCheck("db", 2, fmt.Errorf("connection refused"))
+	require.NoError(t, monitor.RegisterCheck(db, health.WithThresholds(1, 1)))
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	response := AssertResponse(t, monitor.Health, http.StatusServiceUnavailable, health.StatusUnhealthy)
+	check, ok := response.Check("db")
+	require.True(t, ok)
+	assert.Equal(t, "connection refused", check.Error)
+	assert.True(t, clock.Now().Equal(response.At))
+
+	// WHEN
+	AdvanceAndEvaluate(clock, monitor, time.Second)
+	AdvanceAndEvaluate(clock, monitor, time.Second)
+
+	// THEN
+	AssertResponse(t, monitor.Health, http.StatusOK, health.StatusHealthy)
+	assert.Equal(t, 3, db.Evaluations())
+}
+
+func Test_MonitorShouldBeScheduledByFakeClock(t *testing.T) {
+
+	// GIVEN
+	clock := NewFakeClock(time.Now())
+	monitor, err := health.NewMonitor(health.WithClock(clock))
+	require.NoError(t, err)
+	check := NewScriptedCheck("db")
+	require.NoError(t, monitor.RegisterCheck(check, health.WithInterval(time.Minute)))
+
+	// WHEN
+	monitor.Start()
+	defer monitor.Join()
+	defer monitor.Stop()
+
+	// THEN
+	require.Eventually(t, func() bool { return check.Evaluations() == 1 }, time.Second, time.Millisecond)
+
+	// WHEN
+	clock.Advance(time.Second * 59)
+
+	// THEN
+	assert.Never(t, func() bool { return check.Evaluations() > 1 }, time.Millisecond*50, time.Millisecond*5)
+
+	// WHEN
+	clock.Advance(time.Second)
+
+	// THEN
+	assert.Eventually(t, func() bool { return check.Evaluations() == 2 }, time.Second, time.Millisecond)
+}
+
+func Test_MonitorShouldEvaluateChecksIndependently(t *testing.T) {
+
+	// GIVEN
+	clock := NewFakeClock(time.Now())
+	monitor, err := health.NewMonitor(health.WithClock(clock))
+	require.NoError(t, err)
+	release := make(chan struct{})
+	slow, err := health.NewSimpleContextCheck("schema", func(ctx context.Context) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	fast := NewScriptedCheck("memory")
+	require.NoError(t, monitor.RegisterContextCheck(slow, health.WithInterval(time.Minute)))
+	require.NoError(t, monitor.RegisterCheck(fast, health.WithInterval(time.Second)))
+
+	// WHEN
+	monitor.Start()
+	defer monitor.Join()
+	defer monitor.Stop()
+
+	// THEN
+	// the fast check keeps its interval while the slow check is still running
+	require.Eventually(t, func() bool { return fast.Evaluations() == 1 }, time.Second, time.Millisecond)
+	for i := 2; i <= 5; i++ {
+		clock.Advance(time.Second)
+		require.Eventually(t, func() bool { return fast.Evaluations() == i }, time.Second, time.Millisecond)
+	}
+	_, ok := AssertResponse(t, monitor.Health, http.StatusOK, health.StatusHealthy).Check("schema")
+	assert.False(t, ok)
+
+	// WHEN
+	close(release)
+
+	// THEN
+	assert.Eventually(t, func() bool {
+		_, response := Get(t, monitor.Health)
+		_, ok := response.Check("schema")
+		return ok
+	}, time.Second, time.Millisecond)
+}
@@ -0,0 +1,126 @@
+package health
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// HeartbeatCheck is a Check for long running background workers (e.g. queue consumers or schedulers).
+// The worker has to call Beat regularly. The check turns unhealthy in case no beat arrived within the max interval,
+// e.g. because the worker is stuck in a deadlock.
+type HeartbeatCheck struct {
+	name        string
+	maxInterval time.Duration
+
+	// the point in time of the last beat as unix timestamp in nanoseconds
+	lastBeat atomic.Int64
+	now      func() time.Time
+}
+
+// NewHeartbeatCheck creates a HeartbeatCheck that turns unhealthy in case Beat was not called within maxInterval.
+// The first beat is expected within maxInterval after the creation.
+func NewHeartbeatCheck(name string, maxInterval time.Duration) (*HeartbeatCheck, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Can't create a Check with an empty name")
+	}
+
+	if maxInterval <= 0 {
+		return nil, fmt.Errorf("Can't create Check '%s' with a max interval of %s", name, maxInterval)
+	}
+
+	heartbeat := &HeartbeatCheck{
+		name:        name,
+		maxInterval: maxInterval,
+		now:         time.Now,
+	}
+	heartbeat.Beat()
+	return heartbeat, nil
+}
+
+// Beat signals that the worker is still alive
+func (h *HeartbeatCheck) Beat() {
+	h.lastBeat.Store(h.now().UnixNano())
+}
+
+// SinceLastBeat returns the time that has passed since the last beat
+func (h *HeartbeatCheck) SinceLastBeat() time.Duration {
+	return h.now().Sub(time.Unix(0, h.lastBeat.Load()))
+}
+
+// IsHealthy returns an error in case no beat arrived within the max interval
+func (h *HeartbeatCheck) IsHealthy() error {
+	if sinceLastBeat := h.SinceLastBeat(); sinceLastBeat > h.maxInterval {
+		return fmt.Errorf("No heartbeat since %s (expected at least every %s)", sinceLastBeat, h.maxInterval)
+	}
+	return nil
+}
+
+func (h *HeartbeatCheck) String() string {
+	return h.name
+}
+
+// Details reports the time since the last beat
+func (h *HeartbeatCheck) Details() map[string]interface{} {
+	return map[string]interface{}{
+		"since_last_beat_ms": durationToMS(h.SinceLastBeat()),
+		"max_interval_ms":    durationToMS(h.maxInterval),
+	}
+}
+
+// HeartbeatRegistry hands out one named HeartbeatCheck per worker and registers it at the Monitor
+type HeartbeatRegistry struct {
+	monitor     *Monitor
+	maxInterval time.Duration
+	options     []CheckOption
+
+	heartbeats map[string]*HeartbeatCheck
+	mux        sync.Mutex
+}
+
+// NewHeartbeatRegistry creates a HeartbeatRegistry whose HeartbeatChecks are registered at the given Monitor
+// using the given max interval and CheckOptions.
+func NewHeartbeatRegistry(monitor *Monitor, maxInterval time.Duration, options ...CheckOption) (*HeartbeatRegistry, error) {
+	if monitor == nil {
+		return nil, fmt.Errorf("Can't create a HeartbeatRegistry whose Monitor is nil")
+	}
+
+	if maxInterval <= 0 {
+		return nil, fmt.Errorf("Can't create a HeartbeatRegistry with a max interval of %s", maxInterval)
+	}
+
+	return &HeartbeatRegistry{
+		monitor:     monitor,
+		maxInterval: maxInterval,
+		options:     options,
+		heartbeats:  make(map[string]*HeartbeatCheck),
+	}, nil
+}
+
+// Heartbeat returns the HeartbeatCheck with the given name.
+// In case it does not exist yet it is created and registered at the Monitor.
+func (r *HeartbeatRegistry) Heartbeat(name string) (*HeartbeatCheck, error) {
+	r.mux.Lock()
+	defer r.mux.Unlock()
+
+	if heartbeat, ok := r.heartbeats[name]; ok {
+		return heartbeat, nil
+	}
+
+	heartbeat, err := NewHeartbeatCheck(name, r.maxInterval)
+	if err != nil {
+		return nil, err
+	}
+	// the heartbeats share the clock of the monitor
+	heartbeat.now = r.monitor.clock.Now
+	heartbeat.Beat()
+
+	if err := r.monitor.RegisterCheck(heartbeat, r.options...); err != nil {
+		return nil, err
+	}
+	r.heartbeats[name] = heartbeat
+	return heartbeat, nil
+}
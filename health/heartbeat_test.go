@@ -0,0 +1,119 @@
+package health
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_HeartbeatCheck(t *testing.T) {
+
+	// GIVEN
+	now := time.Now()
+	heartbeat, err := NewHeartbeatCheck("consumer", time.Second*10)
+	require.NoError(t, err)
+	require.NotNil(t, heartbeat)
+	heartbeat.now = func() time.Time { return now }
+	heartbeat.Beat()
+
+	// WHEN
+	now = now.Add(time.Second * 10)
+
+	// THEN
+	assert.NoError(t, heartbeat.IsHealthy())
+	assert.Equal(t, time.Second*10, heartbeat.SinceLastBeat())
+	assert.Equal(t, "consumer", heartbeat.String())
+
+	// WHEN
+	now = now.Add(time.Second)
+
+	// THEN
+	assert.EqualError(t, heartbeat.IsHealthy(), "No heartbeat since 11s (expected at least every 10s)")
+	assert.Equal(t, float64(11000), heartbeat.Details()["since_last_beat_ms"])
+
+	// WHEN
+	heartbeat.Beat()
+
+	// THEN
+	assert.NoError(t, heartbeat.IsHealthy())
+	assert.Equal(t, time.Duration(0), heartbeat.SinceLastBeat())
+}
+
+func Test_NewHeartbeatCheckShouldFail(t *testing.T) {
+
+	// WHEN
+	heartbeat, err := NewHeartbeatCheck("", time.Second)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, heartbeat)
+
+	// WHEN
+	heartbeat, err = NewHeartbeatCheck("consumer", 0)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, heartbeat)
+}
+
+func Test_HeartbeatRegistry(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	registry, err := NewHeartbeatRegistry(monitor, time.Second, ForProbes(Liveness))
+	require.NoError(t, err)
+	require.NotNil(t, registry)
+
+	// WHEN
+	consumer, err := registry.Heartbeat("consumer")
+	require.NoError(t, err)
+	scheduler, err := registry.Heartbeat("scheduler")
+	require.NoError(t, err)
+	consumerAgain, err := registry.Heartbeat("consumer")
+	require.NoError(t, err)
+
+	// THEN
+	assert.Same(t, consumer, consumerAgain)
+	assert.NotSame(t, consumer, scheduler)
+	require.Len(t, monitor.healthChecks, 2)
+	assert.Equal(t, "consumer", monitor.healthChecks[0].name)
+	assert.Equal(t, Liveness, monitor.healthChecks[0].probes)
+	assert.Equal(t, "scheduler", monitor.healthChecks[1].name)
+
+	// WHEN
+	checkResult := monitor.evaluateChecks(time.Now())
+
+	// THEN
+	assert.Equal(t, uint(0), checkResult.numErrors)
+	assert.NotNil(t, checkResult.checkResults["consumer"].details["since_last_beat_ms"])
+
+	// WHEN
+	_, err = registry.Heartbeat(" ")
+
+	// THEN
+	assert.Error(t, err)
+}
+
+func Test_NewHeartbeatRegistryShouldFail(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN
+	registry, err := NewHeartbeatRegistry(nil, time.Second)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, registry)
+
+	// WHEN
+	registry, err = NewHeartbeatRegistry(monitor, 0)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, registry)
+}
@@ -0,0 +1,218 @@
+package health
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	defaultResultHistorySize     = 20
+	defaultTransitionHistorySize = 20
+)
+
+// CheckHistory contains the latest results and state transitions of a Check
+type CheckHistory struct {
+	Name string
+	// the latest results of the Check, the oldest one first
+	Results []HistoryEntry
+	// the latest state transitions of the Check, the oldest one first
+	Transitions []Transition
+}
+
+// HistoryEntry is the result of one evaluation of a Check
+type HistoryEntry struct {
+	At       time.Time
+	Status   Status
+	Err      error
+	Duration time.Duration
+	// the message and details reported by a ResultCheck or DetailsProvider
+	Message string
+	Details map[string]interface{}
+}
+
+// Transition is a change of the state of a Check (after applying its thresholds).
+// The first Transition of a Check has no From state, since it was not evaluated before.
+type Transition struct {
+	At       time.Time
+	From     Status
+	To       Status
+	Err      error
+	Duration time.Duration
+}
+
+// ringBuffer keeps the latest entries that were added, dropping the oldest ones
+type ringBuffer[T any] struct {
+	entries []T
+	next    int
+	full    bool
+}
+
+func newRingBuffer[T any](size int) *ringBuffer[T] {
+	if size < 0 {
+		size = 0
+	}
+	return &ringBuffer[T]{entries: make([]T, size)}
+}
+
+func (r *ringBuffer[T]) add(entry T) {
+	if len(r.entries) == 0 {
+		return
+	}
+
+	r.entries[r.next] = entry
+	r.next = (r.next + 1) % len(r.entries)
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+// list returns a copy of the entries, the oldest one first
+func (r *ringBuffer[T]) list() []T {
+	if !r.full {
+		return append([]T{}, r.entries[:r.next]...)
+	}
+	return append(append([]T{}, r.entries[r.next:]...), r.entries[:r.next]...)
+}
+
+type checkHistory struct {
+	results     *ringBuffer[HistoryEntry]
+	transitions *ringBuffer[Transition]
+}
+
+func newCheckHistory(resultHistorySize, transitionHistorySize int) *checkHistory {
+	return &checkHistory{
+		results:     newRingBuffer[HistoryEntry](resultHistorySize),
+		transitions: newRingBuffer[Transition](transitionHistorySize),
+	}
+}
+
+// CheckHistory returns the history of the Check with the given name
+func (m *Monitor) CheckHistory(name string) (CheckHistory, error) {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	for _, check := range m.healthChecks {
+		if check.name == name {
+			return check.historySnapshot(), nil
+		}
+	}
+	return CheckHistory{}, fmt.Errorf("Check '%s' is not registered", name)
+}
+
+// CheckHistories returns the history of all registered checks, sorted by their name
+func (m *Monitor) CheckHistories() []CheckHistory {
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	histories := make([]CheckHistory, 0, len(m.healthChecks))
+	for _, check := range m.healthChecks {
+		histories = append(histories, check.historySnapshot())
+	}
+
+	sort.Slice(histories, func(i, j int) bool {
+		return histories[i].Name < histories[j].Name
+	})
+	return histories
+}
+
+type historyResponse struct {
+	Checks []checkHistoryResponse `json:"checks"`
+}
+
+type checkHistoryResponse struct {
+	Name        string               `json:"name,omitempty"`
+	Results     []historyEntry       `json:"results"`
+	Transitions []transitionResponse `json:"transitions"`
+}
+
+type historyEntry struct {
+	At         time.Time `json:"at,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS float64   `json:"duration_ms"`
+	Message    string    `json:"message,omitempty"`
+
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+type transitionResponse struct {
+	At         time.Time `json:"at,omitempty"`
+	From       string    `json:"from,omitempty"`
+	To         string    `json:"to,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS float64   `json:"duration_ms"`
+}
+
+func checkHistoryToResponse(history CheckHistory) checkHistoryResponse {
+	response := checkHistoryResponse{
+		Name:        history.Name,
+		Results:     make([]historyEntry, 0, len(history.Results)),
+		Transitions: make([]transitionResponse, 0, len(history.Transitions)),
+	}
+
+	for _, result := range history.Results {
+		response.Results = append(response.Results, historyEntry{
+			At:         result.At,
+			Status:     string(result.Status),
+			Error:      errorToString(result.Err),
+			DurationMS: durationToMS(result.Duration),
+			Message:    result.Message,
+			Details:    result.Details,
+		})
+	}
+
+	for _, transition := range history.Transitions {
+		response.Transitions = append(response.Transitions, transitionResponse{
+			At:         transition.At,
+			From:       string(transition.From),
+			To:         string(transition.To),
+			Error:      errorToString(transition.Err),
+			DurationMS: durationToMS(transition.Duration),
+		})
+	}
+	return response
+}
+
+func errorToString(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
+
+func durationToMS(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// History is the endpoint that reports the latest results and state transitions of the checks.
+// The history of a single check can be obtained by specifying its name (e.g. /health/history?check=db).
+func (m *Monitor) History(w http.ResponseWriter, r *http.Request) {
+	m.logger.Debug().Msg("History endpoint called")
+
+	// the history consists of the results of the individual checks only
+	if !m.isAuthorized(r) {
+		http.Error(w, "Not authorized to see the history of the checks", http.StatusForbidden)
+		return
+	}
+
+	var histories []CheckHistory
+	if name := r.URL.Query().Get("check"); len(name) > 0 {
+		history, err := m.CheckHistory(name)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		histories = append(histories, history)
+	} else {
+		histories = m.CheckHistories()
+	}
+
+	response := historyResponse{Checks: make([]checkHistoryResponse, 0, len(histories))}
+	for _, history := range histories {
+		response.Checks = append(response.Checks, m.sanitizeHistory(checkHistoryToResponse(history)))
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
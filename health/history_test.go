@@ -0,0 +1,137 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RingBuffer(t *testing.T) {
+
+	// GIVEN
+	buffer := newRingBuffer[int](3)
+
+	// WHEN
+	buffer.add(1)
+	buffer.add(2)
+
+	// THEN
+	assert.Equal(t, []int{1, 2}, buffer.list())
+
+	// WHEN
+	buffer.add(3)
+	buffer.add(4)
+	buffer.add(5)
+
+	// THEN
+	assert.Equal(t, []int{3, 4, 5}, buffer.list())
+
+	// GIVEN
+	buffer = newRingBuffer[int](0)
+
+	// WHEN
+	buffer.add(1)
+
+	// THEN
+	assert.Empty(t, buffer.list())
+}
+
+func newFlappingMonitor(t *testing.T, options ...Option) (*Monitor, time.Time) {
+	results := []error{nil, fmt.Errorf("no connection"), fmt.Errorf("timeout"), nil}
+	evaluation := 0
+	check, err := NewSimpleCheck("db", func() error {
+		result := results[evaluation%len(results)]
+		evaluation++
+		return result
+	})
+	require.NoError(t, err)
+
+	monitor, err := NewMonitor(options...)
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	err = monitor.Register(check)
+	require.NoError(t, err)
+
+	start := time.Now()
+	for i := range results {
+		monitor.evaluateChecks(start.Add(monitor.checkInterval * time.Duration(i)))
+	}
+	return monitor, start
+}
+
+func Test_CheckHistory(t *testing.T) {
+
+	// GIVEN
+	monitor, start := newFlappingMonitor(t, WithHistorySize(3, 10))
+
+	// WHEN
+	history, err := monitor.CheckHistory("db")
+
+	// THEN
+	require.NoError(t, err)
+	assert.Equal(t, "db", history.Name)
+	require.Len(t, history.Results, 3)
+	assert.Equal(t, StatusUnhealthy, history.Results[0].Status)
+	assert.EqualError(t, history.Results[0].Err, "no connection")
+	assert.Equal(t, start.Add(monitor.checkInterval), history.Results[0].At)
+	assert.Equal(t, StatusHealthy, history.Results[2].Status)
+
+	require.Len(t, history.Transitions, 3)
+	assert.Equal(t, Status(""), history.Transitions[0].From)
+	assert.Equal(t, StatusHealthy, history.Transitions[0].To)
+	assert.Equal(t, StatusHealthy, history.Transitions[1].From)
+	assert.Equal(t, StatusUnhealthy, history.Transitions[1].To)
+	assert.EqualError(t, history.Transitions[1].Err, "no connection")
+	assert.Equal(t, start.Add(monitor.checkInterval), history.Transitions[1].At)
+	assert.Equal(t, StatusUnhealthy, history.Transitions[2].From)
+	assert.Equal(t, StatusHealthy, history.Transitions[2].To)
+
+	// WHEN
+	_, err = monitor.CheckHistory("unknown")
+
+	// THEN
+	assert.Error(t, err)
+}
+
+func Test_HistoryEndpoint(t *testing.T) {
+
+	// GIVEN
+	monitor, _ := newFlappingMonitor(t)
+	req := httptest.NewRequest("GET", "http://example.com/health/history?check=db", nil)
+	w := httptest.NewRecorder()
+
+	// WHEN
+	monitor.History(w, req)
+
+	// THEN
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	respHistory := historyResponse{}
+	err := json.NewDecoder(resp.Body).Decode(&respHistory)
+	require.NoError(t, err)
+	require.Len(t, respHistory.Checks, 1)
+	assert.Equal(t, "db", respHistory.Checks[0].Name)
+	assert.Len(t, respHistory.Checks[0].Results, 4)
+	require.Len(t, respHistory.Checks[0].Transitions, 3)
+	assert.Equal(t, "healthy", respHistory.Checks[0].Transitions[1].From)
+	assert.Equal(t, "unhealthy", respHistory.Checks[0].Transitions[1].To)
+	assert.Equal(t, "no connection", respHistory.Checks[0].Transitions[1].Error)
+
+	// GIVEN
+	req = httptest.NewRequest("GET", "http://example.com/health/history?check=unknown", nil)
+	w = httptest.NewRecorder()
+
+	// WHEN
+	monitor.History(w, req)
+
+	// THEN
+	assert.Equal(t, http.StatusNotFound, w.Result().StatusCode)
+}
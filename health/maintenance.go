@@ -0,0 +1,170 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Maintenance describes the maintenance mode of the service.
+// While the service is in maintenance the readiness probe fails (i.e. the service is removed from the load balancer),
+// but the liveness probe is not affected (i.e. the service is not restarted).
+type Maintenance struct {
+	Reason string
+	Since  time.Time
+	// the maintenance ends automatically at this point in time (zero means it has to be ended explicitly)
+	Until time.Time
+}
+
+type maintenanceResponse struct {
+	Reason string     `json:"reason,omitempty"`
+	Since  time.Time  `json:"since"`
+	Until  *time.Time `json:"until,omitempty"`
+}
+
+type maintenanceRequest struct {
+	Reason string `json:"reason"`
+	// e.g. "30m", empty means the maintenance has to be ended explicitly
+	Duration string `json:"duration,omitempty"`
+}
+
+// EnterMaintenance puts the service into maintenance for the given reason.
+// In case the duration is greater than 0 the maintenance ends automatically after it has elapsed
+// (the end is logged by the running Monitor as soon as it has expired).
+// Entering the maintenance again replaces the current one.
+func (m *Monitor) EnterMaintenance(reason string, duration time.Duration) {
+	now := m.clock.Now()
+	maintenance := &Maintenance{Reason: reason, Since: now}
+	if duration > 0 {
+		maintenance.Until = now.Add(duration)
+	}
+
+	m.maintenanceMux.Lock()
+	m.maintenance = maintenance
+	m.maintenanceMux.Unlock()
+
+	m.logger.Warn().Str("reason", reason).Dur("duration", duration).Bool("no_alert", true).Msg("Entering maintenance, the readiness probe fails until the maintenance ends")
+	// the monitor loop ends the maintenance as soon as it expires
+	m.reschedule()
+}
+
+// LeaveMaintenance ends the maintenance, calling it while the service is not in maintenance has no effect
+func (m *Monitor) LeaveMaintenance() {
+	m.maintenanceMux.Lock()
+	defer m.maintenanceMux.Unlock()
+
+	if m.maintenance == nil {
+		return
+	}
+	m.maintenance = nil
+	m.logger.Info().Msg("Leaving maintenance")
+}
+
+// Maintenance returns the current maintenance, false in case the service is not in maintenance
+func (m *Monitor) Maintenance() (Maintenance, bool) {
+	maintenance := m.activeMaintenance(m.clock.Now())
+	if maintenance == nil {
+		return Maintenance{}, false
+	}
+	return *maintenance, true
+}
+
+// activeMaintenance returns the maintenance at the given point in time, nil in case there is none.
+// An expired maintenance is ended.
+func (m *Monitor) activeMaintenance(now time.Time) *Maintenance {
+	m.maintenanceMux.Lock()
+	defer m.maintenanceMux.Unlock()
+
+	if m.maintenance == nil {
+		return nil
+	}
+
+	if !m.maintenance.Until.IsZero() && !now.Before(m.maintenance.Until) {
+		m.logger.Info().Str("reason", m.maintenance.Reason).Msg("Leaving maintenance, it has expired")
+		m.maintenance = nil
+		return nil
+	}
+	return m.maintenance
+}
+
+// maintenanceUntil returns the point in time the current maintenance expires, zero in case there is none or it has to be ended explicitly
+func (m *Monitor) maintenanceUntil() time.Time {
+	m.maintenanceMux.Lock()
+	defer m.maintenanceMux.Unlock()
+
+	if m.maintenance == nil {
+		return time.Time{}
+	}
+	return m.maintenance.Until
+}
+
+func maintenanceToResponse(maintenance *Maintenance) *maintenanceResponse {
+	if maintenance == nil {
+		return nil
+	}
+
+	response := &maintenanceResponse{Reason: maintenance.Reason, Since: maintenance.Since}
+	if !maintenance.Until.IsZero() {
+		until := maintenance.Until
+		response.Until = &until
+	}
+	return response
+}
+
+// MaintenanceHandler is the admin endpoint for the maintenance mode, it should only be reachable for operators.
+//   - GET returns the current maintenance (404 in case the service is not in maintenance)
+//   - POST enters the maintenance, e.g. {"reason":"migration","duration":"30m"}
+//   - DELETE leaves the maintenance
+//
+// Only callers that are authorized by one of the authorizers passed to WithMaintenanceAuthorizers may enter or leave the maintenance,
+// without them POST and DELETE are rejected. GET is available for them and for callers that may see the details (see WithRestrictedDetails).
+func (m *Monitor) MaintenanceHandler(w http.ResponseWriter, r *http.Request) {
+	m.logger.Debug().Str("method", r.Method).Msg("Maintenance endpoint called")
+
+	authorized := isAuthorizedBy(r, m.maintenanceAuthorizers)
+	if r.Method == http.MethodGet {
+		authorized = authorized || m.isAuthorized(r)
+	}
+	if !authorized {
+		http.Error(w, "Not authorized to access the maintenance", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		var req maintenanceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, fmt.Sprintf("Unable to parse request: %s", err), http.StatusBadRequest)
+			return
+		}
+
+		var duration time.Duration
+		if len(req.Duration) > 0 {
+			d, err := time.ParseDuration(req.Duration)
+			if err != nil || d < 0 {
+				http.Error(w, fmt.Sprintf("Invalid duration '%s'", req.Duration), http.StatusBadRequest)
+				return
+			}
+			duration = d
+		}
+		m.EnterMaintenance(req.Reason, duration)
+	case http.MethodDelete:
+		m.LeaveMaintenance()
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	maintenance := m.activeMaintenance(m.clock.Now())
+	if maintenance == nil {
+		http.Error(w, "Not in maintenance", http.StatusNotFound)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, maintenanceToResponse(maintenance))
+}
@@ -0,0 +1,255 @@
+package health
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fixedClock is a Clock whose time only changes by setting now
+type fixedClock struct {
+	realClock
+	now time.Time
+}
+
+func (c *fixedClock) Now() time.Time {
+	return c.now
+}
+
+func Test_MaintenanceShouldFailReadinessOnly(t *testing.T) {
+
+	// GIVEN
+	logs := bytes.Buffer{}
+	monitor, err := NewMonitor(WithLogger(zerolog.New(&logs)))
+	require.NoError(t, err)
+	monitor.EvaluateNow()
+
+	// WHEN
+	monitor.EnterMaintenance("database migration", 0)
+
+	// THEN
+	readiness := httptest.NewRecorder()
+	monitor.Readiness(readiness, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, readiness.Code)
+	var readinessResponse response
+	require.NoError(t, json.Unmarshal(readiness.Body.Bytes(), &readinessResponse))
+	assert.Equal(t, "maintenance", readinessResponse.Status)
+	require.NotNil(t, readinessResponse.Maintenance)
+	assert.Equal(t, "database migration", readinessResponse.Maintenance.Reason)
+	assert.Nil(t, readinessResponse.Maintenance.Until)
+
+	liveness := httptest.NewRecorder()
+	monitor.Liveness(liveness, httptest.NewRequest(http.MethodGet, "/health/live", nil))
+	assert.Equal(t, http.StatusOK, liveness.Code)
+
+	health := httptest.NewRecorder()
+	monitor.Health(health, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, health.Code)
+	assert.Contains(t, health.Body.String(), `"maintenance":{"reason":"database migration"`)
+	assert.Contains(t, logs.String(), "Entering maintenance")
+
+	// WHEN
+	monitor.LeaveMaintenance()
+
+	// THEN
+	readiness = httptest.NewRecorder()
+	monitor.Readiness(readiness, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+	assert.Equal(t, http.StatusOK, readiness.Code)
+	assert.NotContains(t, readiness.Body.String(), "maintenance")
+	assert.Contains(t, logs.String(), "Leaving maintenance")
+}
+
+func Test_MaintenanceShouldExpire(t *testing.T) {
+
+	// GIVEN
+	clock := &fixedClock{now: time.Now()}
+	monitor, err := NewMonitor(WithClock(clock))
+	require.NoError(t, err)
+	monitor.EnterMaintenance("deployment", time.Minute)
+
+	// WHEN
+	clock.now = clock.now.Add(time.Second * 59)
+	maintenance, inMaintenance := monitor.Maintenance()
+
+	// THEN
+	assert.True(t, inMaintenance)
+	assert.Equal(t, "deployment", maintenance.Reason)
+	assert.Equal(t, maintenance.Since.Add(time.Minute), maintenance.Until)
+
+	// WHEN
+	clock.now = clock.now.Add(time.Second)
+	_, inMaintenance = monitor.Maintenance()
+
+	// THEN
+	assert.False(t, inMaintenance)
+}
+
+// lockedBuffer is a bytes.Buffer that can be written by the monitor and read by the test concurrently
+type lockedBuffer struct {
+	mux sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+	return b.buf.String()
+}
+
+func Test_MaintenanceShouldBeEndedByTheMonitorWhenItExpires(t *testing.T) {
+
+	// GIVEN
+	logs := &lockedBuffer{}
+	monitor, err := NewMonitor(WithLogger(zerolog.New(logs)))
+	require.NoError(t, err)
+	monitor.Start()
+	defer monitor.Join()
+	defer monitor.Stop()
+
+	// WHEN
+	monitor.EnterMaintenance("deployment", time.Millisecond*50)
+
+	// THEN
+	// the end is logged although nobody asks for the maintenance (way before the next regular evaluation)
+	assert.Eventually(t, func() bool { return strings.Contains(logs.String(), "Leaving maintenance, it has expired") }, time.Second, time.Millisecond*5)
+}
+
+// newMaintenanceRequest creates a request to the maintenance endpoint that is authorized by TokenAuthorizer("secret")
+func newMaintenanceRequest(method string, body string) *http.Request {
+	r := httptest.NewRequest(method, "/health/maintenance", strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer secret")
+	return r
+}
+
+func Test_MaintenanceHandler(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor(WithMaintenanceAuthorizers(TokenAuthorizer("secret")))
+	require.NoError(t, err)
+
+	// WHEN
+	recorder := httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodGet, ""))
+
+	// THEN
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	// WHEN
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodPost, `{"reason":"migration","duration":"30m"}`))
+
+	// THEN
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var resp maintenanceResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	assert.Equal(t, "migration", resp.Reason)
+	require.NotNil(t, resp.Until)
+	assert.Equal(t, resp.Since.Add(time.Minute*30), *resp.Until)
+
+	// WHEN
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodDelete, ""))
+
+	// THEN
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	_, inMaintenance := monitor.Maintenance()
+	assert.False(t, inMaintenance)
+}
+
+func Test_MaintenanceHandlerShouldRejectInvalidRequests(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor(WithMaintenanceAuthorizers(TokenAuthorizer("secret")))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	recorder := httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodPost, `{"duration":"soon"}`))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodPut, ""))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, httptest.NewRequest(http.MethodPost, "/health/maintenance", strings.NewReader(`{"reason":"migration"}`)))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+	_, inMaintenance := monitor.Maintenance()
+	assert.False(t, inMaintenance)
+}
+
+func Test_MaintenanceHandlerShouldRejectChangesWithoutAuthorizer(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	monitor.EnterMaintenance("migration", 0)
+
+	// WHEN + THEN
+	recorder := httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, httptest.NewRequest(http.MethodGet, "/health/maintenance", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodPost, `{"reason":"other"}`))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodDelete, ""))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	maintenance, inMaintenance := monitor.Maintenance()
+	assert.True(t, inMaintenance)
+	assert.Equal(t, "migration", maintenance.Reason)
+}
+
+func Test_MaintenanceHandlerShouldNotAuthorizeChangesByTheDetailsAuthorizers(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor(WithRestrictedDetails(TokenAuthorizer("dashboard")), WithMaintenanceAuthorizers(TokenAuthorizer("secret")))
+	require.NoError(t, err)
+	monitor.EnterMaintenance("migration", 0)
+	newDashboardRequest := func(method string, body string) *http.Request {
+		r := httptest.NewRequest(method, "/health/maintenance", strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer dashboard")
+		return r
+	}
+
+	// WHEN + THEN
+	recorder := httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newDashboardRequest(http.MethodGet, ""))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, httptest.NewRequest(http.MethodGet, "/health/maintenance", nil))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newDashboardRequest(http.MethodDelete, ""))
+	assert.Equal(t, http.StatusForbidden, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodGet, ""))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	monitor.MaintenanceHandler(recorder, newMaintenanceRequest(http.MethodDelete, ""))
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	_, inMaintenance := monitor.Maintenance()
+	assert.False(t, inMaintenance)
+}
@@ -0,0 +1,134 @@
+package health
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// the content type of the prometheus text exposition format
+const metricsContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+type metricFamily struct {
+	name    string
+	help    string
+	samples []metricSample
+}
+
+type metricSample struct {
+	labelName  string
+	labelValue string
+	value      float64
+}
+
+// checkEvaluationResultToMetrics converts the given result into metrics,
+// the metrics of the individual checks are only included in case includeChecks is true
+func checkEvaluationResultToMetrics(cer checkEvaluationResult, now time.Time, checkEvaluationTimeout time.Duration, includeChecks bool) []metricFamily {
+	status := overallStatus(cer, now, checkEvaluationTimeout)
+
+	statusFamily := metricFamily{name: "health_status", help: "The overall health status of the service (1 for the current status, 0 otherwise)."}
+	for _, s := range []Status{StatusHealthy, StatusDegraded, StatusUnhealthy} {
+		statusFamily.samples = append(statusFamily.samples, metricSample{labelName: "status", labelValue: string(s), value: boolToFloat(s == status)})
+	}
+
+	families := []metricFamily{
+		{
+			name:    "health_up",
+			help:    "Whether the service is able to handle requests (1) or not (0), a degraded service is regarded as up.",
+			samples: []metricSample{{value: boolToFloat(status != StatusUnhealthy)}},
+		},
+		statusFamily,
+		{
+			name:    "health_last_evaluation_timestamp_seconds",
+			help:    "The point in time the checks were evaluated last, as unix timestamp in seconds.",
+			samples: []metricSample{{value: toUnixSeconds(cer.at)}},
+		},
+	}
+	if !includeChecks {
+		return families
+	}
+
+	// sorted by name to obtain a stable output
+	names := make([]string, 0, len(cer.checkResults))
+	for name := range cer.checkResults {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	checkUp := metricFamily{name: "health_check_up", help: "Whether the check is healthy (1) or not (0)."}
+	checkDuration := metricFamily{name: "health_check_duration_seconds", help: "The duration of the latest evaluation of the check in seconds."}
+	checkFailures := metricFamily{name: "health_check_consecutive_failures", help: "The number of consecutive failed evaluations of the check."}
+	checkLastEvaluation := metricFamily{name: "health_check_last_evaluation_timestamp_seconds", help: "The point in time the check was evaluated last, as unix timestamp in seconds."}
+	for _, name := range names {
+		cr := cer.checkResults[name]
+		checkUp.samples = append(checkUp.samples, metricSample{labelName: "check", labelValue: name, value: boolToFloat(cr.err == nil)})
+		checkDuration.samples = append(checkDuration.samples, metricSample{labelName: "check", labelValue: name, value: cr.duration.Seconds()})
+		checkFailures.samples = append(checkFailures.samples, metricSample{labelName: "check", labelValue: name, value: float64(cr.consecutiveFailures)})
+		checkLastEvaluation.samples = append(checkLastEvaluation.samples, metricSample{labelName: "check", labelValue: name, value: toUnixSeconds(cr.evaluatedAt)})
+	}
+
+	return append(families, checkUp, checkDuration, checkFailures, checkLastEvaluation)
+}
+
+// writeMetrics writes the given metrics in the prometheus text exposition format
+func writeMetrics(w io.Writer, families []metricFamily) error {
+	bw := bufio.NewWriter(w)
+	for _, family := range families {
+		fmt.Fprintf(bw, "# HELP %s %s\n", family.name, escapeHelp(family.help))
+		fmt.Fprintf(bw, "# TYPE %s gauge\n", family.name)
+		for _, sample := range family.samples {
+			bw.WriteString(family.name)
+			if len(sample.labelName) > 0 {
+				fmt.Fprintf(bw, "{%s=\"%s\"}", sample.labelName, escapeLabelValue(sample.labelValue))
+			}
+			fmt.Fprintf(bw, " %s\n", strconv.FormatFloat(sample.value, 'g', -1, 64))
+		}
+	}
+	return bw.Flush()
+}
+
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+
+func escapeHelp(help string) string {
+	return helpEscaper.Replace(help)
+}
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func toUnixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// Metrics is the endpoint that reports the health state in the prometheus text exposition format.
+// The metrics of the individual checks are only reported to authorized callers (see WithRestrictedDetails).
+func (m *Monitor) Metrics(w http.ResponseWriter, r *http.Request) {
+	m.logger.Debug().Msg("Metrics endpoint called")
+
+	latestResult := m.latestCheckResult.Load().(checkEvaluationResult)
+	families := checkEvaluationResultToMetrics(latestResult, m.clock.Now(), m.checkEvaluationTimeout, m.isAuthorized(r))
+
+	w.Header().Add("Content-Type", metricsContentType)
+	w.WriteHeader(http.StatusOK)
+
+	if err := writeMetrics(w, families); err != nil {
+		m.logger.Error().Err(err).Msg("Failed writing metrics")
+	}
+}
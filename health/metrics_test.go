@@ -0,0 +1,111 @@
+package health
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var updateGoldenFiles = flag.Bool("update", false, "update the golden files")
+
+const metricsGoldenFile = "../test/data/health_metrics.golden"
+
+func Test_WriteMetricsShouldMatchGoldenFile(t *testing.T) {
+
+	// GIVEN
+	at := time.Date(2020, 4, 27, 10, 30, 0, 0, time.UTC)
+	results := make(map[string]checkResult)
+	results["db"] = checkResult{
+		err:                 fmt.Errorf("No connection"),
+		evaluatedAt:         at,
+		duration:            time.Millisecond * 1500,
+		consecutiveFailures: 3,
+	}
+	results["cache"] = checkResult{
+		evaluatedAt: at.Add(-time.Second * 10),
+		duration:    time.Millisecond * 5,
+	}
+	results[`quoted "check"\`] = checkResult{
+		evaluatedAt: at,
+		severity:    NonCritical,
+	}
+	cer := checkEvaluationResult{at: at, checkResults: results, numErrors: 1}
+	families := checkEvaluationResultToMetrics(cer, at, time.Second*30, true)
+
+	// WHEN
+	var buffer bytes.Buffer
+	err := writeMetrics(&buffer, families)
+
+	// THEN
+	require.NoError(t, err)
+	if *updateGoldenFiles {
+		err = os.WriteFile(metricsGoldenFile, buffer.Bytes(), 0644)
+		require.NoError(t, err)
+	}
+	golden, err := os.ReadFile(metricsGoldenFile)
+	require.NoError(t, err)
+	assert.Equal(t, string(golden), buffer.String())
+}
+
+func Test_MetricsEndpoint(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	req := httptest.NewRequest("GET", "http://example.com/metrics", nil)
+	w := httptest.NewRecorder()
+	results := make(map[string]checkResult)
+	results["cache"] = checkResult{err: fmt.Errorf("No connection"), severity: NonCritical}
+	monitor.latestCheckResult.Store(checkEvaluationResult{
+		at:           time.Now(),
+		numErrors:    1,
+		checkResults: results,
+	})
+
+	// WHEN
+	monitor.Metrics(w, req)
+
+	// THEN
+	resp := w.Result()
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, metricsContentType, resp.Header.Get("Content-Type"))
+	body := w.Body.String()
+	assert.Contains(t, body, "health_up 1\n")
+	assert.Contains(t, body, "health_status{status=\"degraded\"} 1\n")
+	assert.Contains(t, body, "health_check_up{check=\"cache\"} 0\n")
+}
+
+func Test_MetricsEndpointShouldRestrictCheckMetrics(t *testing.T) {
+
+	// GIVEN
+	monitor := newRestrictedMonitor(t, WithRestrictedDetails(TokenAuthorizer("secret")))
+	anonymous := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	authorized := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	authorized.Header.Set("Authorization", "Bearer secret")
+
+	// WHEN
+	anonymousRecorder := httptest.NewRecorder()
+	monitor.Metrics(anonymousRecorder, anonymous)
+	authorizedRecorder := httptest.NewRecorder()
+	monitor.Metrics(authorizedRecorder, authorized)
+
+	// THEN
+	anonymousBody := anonymousRecorder.Body.String()
+	assert.Contains(t, anonymousBody, "health_up 0\n")
+	assert.Contains(t, anonymousBody, "health_status{status=\"unhealthy\"} 1\n")
+	assert.Contains(t, anonymousBody, "health_last_evaluation_timestamp_seconds ")
+	assert.NotContains(t, anonymousBody, "health_check_")
+	assert.NotContains(t, anonymousBody, "db")
+
+	assert.Contains(t, authorizedRecorder.Body.String(), "health_check_up{check=\"db\"} 0\n")
+}
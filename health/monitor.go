@@ -1,8 +1,9 @@
 package health
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -10,26 +11,89 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// lifecycleState is the state of the lifecycle of a Monitor (created -> running -> stopped)
+type lifecycleState int
+
+const (
+	stateCreated lifecycleState = iota
+	stateRunning
+	stateStopped
+)
+
+func (s lifecycleState) String() string {
+	switch s {
+	case stateCreated:
+		return "created"
+	case stateRunning:
+		return "running"
+	case stateStopped:
+		return "stopped"
+	}
+	return "unknown"
+}
+
 // Monitor represents a monitor for the health state of a service
 type Monitor struct {
 	// the registered health checks
-	healthChecks []Check
+	healthChecks []*registeredCheck
 
+	// the interval the checks are evaluated in, unless a check specifies its own interval
 	checkInterval time.Duration
 
+	// the number of checks that are evaluated concurrently at most (0 means no limit)
+	maxConcurrentChecks int
+
 	// in case the check evaluation has not been done within
 	// checkEvaluationTimeout the health status will change to unhealthy
 	checkEvaluationTimeout time.Duration
 	latestCheckResult      atomic.Value
 
+	// the number of results and state transitions that are kept per check
+	resultHistorySize     int
+	transitionHistorySize int
+
 	wg sync.WaitGroup
-	// channel used to signal teardown/ stop
-	stopChan chan struct{}
+	// the state of the lifecycle of the monitor, guarded by lifecycleMux
+	state lifecycleState
+	// the context of the current run and the function to stop it (nil as long as the monitor was never started)
+	runCtx       context.Context
+	cancelRun    context.CancelFunc
+	lifecycleMux sync.Mutex
+	// channel used to signal that the schedule of the checks has changed
+	rescheduleChan chan struct{}
+	// the evaluations that are in progress
+	evaluations sync.WaitGroup
+	// limits the number of checks that are evaluated concurrently (nil means no limit)
+	slots chan struct{}
 
 	logger zerolog.Logger
+	clock  Clock
+
+	// the file the latest result is written to after each evaluation (empty means no file is written)
+	statusFile string
+
+	// in case the details are restricted only callers authorized by one of the authorizers get the results of the individual checks
+	restrictDetails bool
+	authorizers     []Authorizer
+	errorSanitizer  ErrorSanitizer
+
+	// only callers authorized by one of these authorizers may enter or leave the maintenance using the MaintenanceHandler
+	maintenanceAuthorizers []Authorizer
+
+	// the current maintenance, nil in case the service is not in maintenance
+	maintenance    *Maintenance
+	maintenanceMux sync.Mutex
 
 	// will be called each time the monitor evaluates the checks
-	onCheckCallback OnCheckFun
+	onCheckCallback       OnCheckFun
+	onCheckStatusCallback OnCheckStatusFun
+
+	// the overall status of the latest evaluation, used to detect changes
+	latestStatus Status
+
+	// the subscribers that are informed about changes of the status
+	subscribers    map[*subscriber]struct{}
+	subscribersMux sync.RWMutex
 
 	mux sync.RWMutex
 }
@@ -38,131 +102,645 @@ type checkEvaluationResult struct {
 	at        time.Time
 	numErrors uint
 	// a map that contains one entry per check
-	// if the check was healthy then the entry (error) is nil
+	// if the check was healthy then the entry (err) is nil
 	// if the check was NOT healthy then the entry contains the according error
-	checkHealthyness map[string]error
+	checkResults map[string]checkResult
+
+	// true as soon as each check of the Startup probe has been healthy at least once
+	startupCompleted bool
+}
+
+type checkResult struct {
+	err         error
+	probes      Probe
+	severity    Severity
+	evaluatedAt time.Time
+	duration    time.Duration
+	details     map[string]interface{}
+	// the results of the children of a check consisting of other checks
+	children []ChildResult
+	// true in case the check succeeded, but is regarded as degraded (e.g. since it exceeded its latency budget)
+	degraded bool
+	// the reason why the check is degraded (nil in case it exceeded its latency budget)
+	degradedErr error
+	message     string
+	// the point in time the check is evaluated next and the delay in case it is backing off
+	nextEvaluationAt time.Time
+	backoffDelay     time.Duration
+
+	consecutiveFailures  uint
+	consecutiveSuccesses uint
+}
+
+// status returns the overall status based on the results of the checks.
+// A failing Critical check results in StatusUnhealthy, a failing NonCritical check in StatusDegraded.
+func (cer checkEvaluationResult) status() Status {
+	status := StatusHealthy
+	for _, cr := range cer.checkResults {
+		if cr.err == nil {
+			if cr.degraded {
+				status = StatusDegraded
+			}
+			continue
+		}
+		if cr.severity == Critical {
+			return StatusUnhealthy
+		}
+		status = StatusDegraded
+	}
+	return status
+}
+
+// forProbe returns the part of the result that belongs to the given probe
+func (cer checkEvaluationResult) forProbe(probe Probe) checkEvaluationResult {
+	result := checkEvaluationResult{
+		at:               cer.at,
+		numErrors:        0,
+		checkResults:     make(map[string]checkResult),
+		startupCompleted: cer.startupCompleted,
+	}
+
+	for name, cr := range cer.checkResults {
+		if !cr.probes.Has(probe) {
+			continue
+		}
+		result.checkResults[name] = cr
+		if cr.err != nil {
+			result.numErrors++
+		}
+	}
+	return result
 }
 
 // NewMonitor creates a new health monitor
 func NewMonitor(options ...Option) (*Monitor, error) {
 	monitor := &Monitor{
-		healthChecks:           make([]Check, 0),
+		healthChecks:           make([]*registeredCheck, 0),
 		checkInterval:          time.Second * 5,
 		checkEvaluationTimeout: time.Second * 30,
-		stopChan:               make(chan struct{}),
+		rescheduleChan:         make(chan struct{}, 1),
+		resultHistorySize:      defaultResultHistorySize,
+		transitionHistorySize:  defaultTransitionHistorySize,
 		onCheckCallback:        nil,
+		subscribers:            make(map[*subscriber]struct{}),
+		clock:                  realClock{},
 	}
 
-	checkResult := checkEvaluationResult{
-		at:               time.Now(),
-		numErrors:        0,
-		checkHealthyness: make(map[string]error),
-	}
-	monitor.latestCheckResult.Store(checkResult)
-
 	// apply the options
 	for _, opt := range options {
 		opt(monitor)
 	}
 
+	if monitor.clock == nil {
+		return nil, fmt.Errorf("Unable to create a Monitor without a clock")
+	}
+	if monitor.maxConcurrentChecks > 0 {
+		monitor.slots = make(chan struct{}, monitor.maxConcurrentChecks)
+	}
+
+	checkResult := checkEvaluationResult{
+		at:           monitor.clock.Now(),
+		numErrors:    0,
+		checkResults: make(map[string]checkResult),
+	}
+	monitor.latestCheckResult.Store(checkResult)
+
 	return monitor, nil
 }
 
-// Start starts the monitoring
+// Start starts the monitoring in the background.
+// Calling Start on a running Monitor has no effect, a stopped Monitor is started again.
 func (m *Monitor) Start() {
+	ctx, ok := m.begin(context.Background())
+	if !ok {
+		return
+	}
 
-	go m.monitor(m.checkInterval)
-	m.logger.Info().Msg("Monitor started")
+	go func() {
+		defer m.wg.Done()
+		m.monitor(ctx)
+	}()
+}
+
+// Run runs the monitoring and blocks until the given context is done or Stop was called.
+// An error is returned in case the Monitor is already running.
+func (m *Monitor) Run(ctx context.Context) error {
+	ctx, ok := m.begin(ctx)
+	if !ok {
+		return fmt.Errorf("Unable to run %s, it is already running", m)
+	}
+
+	defer m.wg.Done()
+	m.monitor(ctx)
+	return nil
 }
 
-// Stop stops the monitoring
+// Stop stops the monitoring, calling Stop on a Monitor that is not running has no effect.
+// The evaluations that are in progress are aborted, a ContextCheck is informed about this through its context.
 func (m *Monitor) Stop() error {
+	m.lifecycleMux.Lock()
+	defer m.lifecycleMux.Unlock()
+
+	if m.state != stateRunning {
+		return nil
+	}
+
 	m.logger.Info().Msg("Teardown requested")
-	close(m.stopChan)
+	m.cancelRun()
+	m.state = stateStopped
 	return nil
 }
 
-// Join waits until the monitorhas been stopped
+// Join waits until the monitor has been stopped
 func (m *Monitor) Join() {
 	m.wg.Wait()
 }
 
+// begin transitions the monitor into the running state and returns the context of the new run.
+// False is returned in case the monitor is already running.
+func (m *Monitor) begin(parent context.Context) (context.Context, bool) {
+	m.lifecycleMux.Lock()
+	defer m.lifecycleMux.Unlock()
+
+	if m.state == stateRunning {
+		return nil, false
+	}
+
+	// the wait group is incremented before the monitor loop starts, hence Join can't return too early
+	m.wg.Add(1)
+	m.runCtx, m.cancelRun = context.WithCancel(parent)
+	m.state = stateRunning
+	m.logger.Info().Msg("Monitor started")
+	return m.runCtx, true
+}
+
+// end transitions the monitor into the stopped state after the run with the given context has ended
+// (e.g. because its parent context is done)
+func (m *Monitor) end(ctx context.Context) {
+	m.lifecycleMux.Lock()
+	defer m.lifecycleMux.Unlock()
+
+	// the monitor might have been stopped and started again in the meantime
+	if m.runCtx != ctx {
+		return
+	}
+	m.cancelRun()
+	m.state = stateStopped
+}
+
 func (m *Monitor) String() string {
 	m.mux.Lock()
 	defer m.mux.Unlock()
 	return fmt.Sprintf("HealthMonitor (%d checks)", len(m.healthChecks))
 }
 
-func (m *Monitor) monitor(checkInterval time.Duration) {
-	m.wg.Add(1)
-	defer m.wg.Done()
+func (m *Monitor) monitor(ctx context.Context) {
+	defer m.end(ctx)
+	// the evaluations of this run are aborted via ctx, they have to be done before the run is regarded as ended
+	defer m.evaluations.Wait()
 
-	checkIntervalTicker := time.NewTicker(checkInterval)
+	// the first evaluation is done right away, since none of the checks has been evaluated yet
+	nextEvaluationTimer := m.clock.NewTimer(m.nextEvaluationIn(m.clock.Now()))
+	defer nextEvaluationTimer.Stop()
 
 	for {
 		select {
-		case <-m.stopChan:
+		case <-ctx.Done():
 			m.logger.Info().Msg("Monitor stopped")
 			return
-		case <-checkIntervalTicker.C:
-			now := time.Now()
-			latestCheckResult := m.evaluateChecks(now)
-			m.latestCheckResult.Store(latestCheckResult)
+		case <-m.rescheduleChan:
+			if !nextEvaluationTimer.Stop() {
+				<-nextEvaluationTimer.C()
+			}
+		case <-nextEvaluationTimer.C():
+			now := m.clock.Now()
+			// ends (and logs) a maintenance that has expired in the meantime
+			m.activeMaintenance(now)
+			// each check is evaluated on its own, hence a slow check does not delay the others
+			if len(m.launchDueChecks(ctx, now)) == 0 {
+				// the result is refreshed anyway, since its age indicates whether the monitor itself is alive
+				m.refresh(now)
+			}
+		}
+		nextEvaluationTimer.Reset(m.nextEvaluationIn(m.clock.Now()))
+	}
+}
+
+// nextEvaluationIn returns the duration until the next check has to be evaluated.
+// Checks whose evaluation is in progress are not considered, they are rescheduled as soon as they are done.
+// The duration is at most m.checkInterval and ends at the latest when the current maintenance expires.
+func (m *Monitor) nextEvaluationIn(now time.Time) time.Duration {
+	next := m.checkInterval
+	if until := m.maintenanceUntil(); !until.IsZero() && until.Sub(now) < next {
+		next = until.Sub(now)
+	}
+
+	m.mux.RLock()
+	defer m.mux.RUnlock()
+
+	for _, check := range m.healthChecks {
+		if check.running != nil {
+			continue
 		}
+		dueIn := check.nextEvaluationAt(m.checkInterval).Sub(now)
+		if dueIn < next {
+			next = dueIn
+		}
+	}
+
+	if next < 0 {
+		return 0
 	}
+	return next
 }
 
+// evaluateChecks evaluates all checks that are due at the given point in time and waits until they are done.
+// The returned result contains the latest result of each check, regardless whether it was evaluated now or earlier.
 func (m *Monitor) evaluateChecks(at time.Time) checkEvaluationResult {
-	// guard m.healthChecks
+	running := m.launchDueChecks(context.Background(), at)
+	if len(running) == 0 {
+		m.refresh(at)
+	}
+	for _, done := range running {
+		<-done
+	}
+	return m.latestCheckResult.Load().(checkEvaluationResult)
+}
+
+// EvaluateNow evaluates all checks right away, regardless whether they are due or not, and waits until they are done.
+// The Monitor does not need to be running, hence this can be used to trigger the evaluation in tests.
+// For a check whose evaluation is already in progress EvaluateNow waits for this evaluation instead of starting another one.
+func (m *Monitor) EvaluateNow() {
+	now := m.clock.Now()
+
+	m.mux.Lock()
+	running := make([]<-chan struct{}, 0, len(m.healthChecks))
+	for _, check := range m.healthChecks {
+		if check.running == nil {
+			m.launch(context.Background(), check, now)
+		}
+		running = append(running, check.running)
+	}
+	m.mux.Unlock()
+
+	if len(running) == 0 {
+		m.refresh(now)
+	}
+	for _, done := range running {
+		<-done
+	}
+	// the schedule of the monitor loop has to consider the new results
+	m.reschedule()
+}
+
+// launchDueChecks starts the evaluation of each check that is due at the given point in time and not evaluated already.
+// The returned channels are closed as soon as the according evaluation is done.
+func (m *Monitor) launchDueChecks(ctx context.Context, at time.Time) []<-chan struct{} {
 	m.mux.Lock()
 	defer m.mux.Unlock()
 
+	var running []<-chan struct{}
+	for _, check := range m.healthChecks {
+		if check.running != nil || check.nextEvaluationAt(m.checkInterval).After(at) {
+			continue
+		}
+		running = append(running, m.launch(ctx, check, at))
+	}
+	return running
+}
+
+// launch starts the evaluation of the given check in the background, its result is recorded as soon as it is done.
+// In case the given context is done (e.g. since the monitor was stopped) the evaluation is aborted and its result is discarded.
+// m.mux has to be held by the caller.
+func (m *Monitor) launch(ctx context.Context, check *registeredCheck, at time.Time) <-chan struct{} {
+	done := make(chan struct{})
+	check.running = done
+
+	m.evaluations.Add(1)
+	go func() {
+		defer m.evaluations.Done()
+		defer close(done)
+
+		eval := check.evaluate(ctx, m.clock, m.slots)
+
+		m.mux.Lock()
+		check.running = nil
+		if ctx.Err() != nil {
+			m.mux.Unlock()
+			m.logger.Debug().Msgf("Evaluation of check '%s' aborted", check.name)
+			return
+		}
+		events := m.record(check, eval, at)
+		result, statusEvents := m.updateLatestResult(at)
+		m.mux.Unlock()
+
+		m.notify(result, append(events, statusEvents...))
+		m.reschedule()
+	}()
+	return done
+}
+
+// record records the given evaluation of the given check and logs it.
+// The returned events contain the change of the status of the check (if any).
+// m.mux has to be held by the caller.
+func (m *Monitor) record(check *registeredCheck, eval evaluation, at time.Time) []Event {
+	var events []Event
+	err := eval.errOrDegraded()
+	if transition, hasChanged := check.record(eval, at); hasChanged {
+		events = append(events, Event{
+			Type:  CheckStatusChanged,
+			At:    at,
+			Check: check.name,
+			From:  transition.From,
+			To:    transition.To,
+			Err:   transition.Err,
+		})
+	}
+
+	logEvent := m.logger.Debug()
+	if err != nil && (check.severity == NonCritical || IsDegraded(err)) {
+		logEvent = m.logger.Warn()
+	} else if err != nil {
+		logEvent = m.logger.Error()
+	}
+	logEvent.Err(err).
+		// don't propagate errors to alerting
+		Bool("no_alert", true).
+		Msgf("Check - '%s'", check.name)
+
+	if check.isSlow() {
+		m.logger.Warn().
+			Dur("duration", check.lastDuration).
+			Dur("latency_budget", check.latencyBudget).
+			Bool("no_alert", true).
+			Msgf("Check - '%s' exceeded its latency budget", check.name)
+	}
+
+	var panicErr *panicError
+	if errors.As(err, &panicErr) {
+		m.logger.Error().
+			Str("stack", string(panicErr.stack)).
+			Bool("no_alert", true).
+			Msgf("Check - '%s' panicked: %v", check.name, panicErr.value)
+	}
+	return events
+}
+
+// refresh updates the latest result at the given point in time without evaluating any check
+// (e.g. to detect stale results and to show that the monitor is still alive)
+func (m *Monitor) refresh(at time.Time) {
+	m.mux.Lock()
+	result, events := m.updateLatestResult(at)
+	m.mux.Unlock()
+
+	m.notify(result, events)
+}
+
+// updateLatestResult assembles and stores the latest result at the given point in time.
+// The returned events contain the change of the overall status (if any).
+// m.mux has to be held by the caller, hence the result can't contain checks that were unregistered in the meantime.
+func (m *Monitor) updateLatestResult(at time.Time) (checkEvaluationResult, []Event) {
+	var events []Event
+	result := m.latestResult(at)
+	status := result.status()
+	if status != m.latestStatus {
+		events = append(events, Event{Type: StatusChanged, At: at, From: m.latestStatus, To: status})
+		m.latestStatus = status
+	}
+	m.latestCheckResult.Store(result)
+	return result, events
+}
+
+// notify informs the status file, the subscribers and the callbacks about the given result
+func (m *Monitor) notify(result checkEvaluationResult, events []Event) {
+	status := result.status()
+	m.writeStatusFile(result)
+	m.publish(events)
+	if m.onCheckCallback != nil {
+		m.onCheckCallback(status != StatusUnhealthy, result.numErrors)
+	}
+	if m.onCheckStatusCallback != nil {
+		m.onCheckStatusCallback(status, result.numErrors)
+	}
+}
+
+// latestResult assembles the latest results of all checks that have been evaluated so far.
+// m.mux has to be held by the caller.
+func (m *Monitor) latestResult(at time.Time) checkEvaluationResult {
 	result := checkEvaluationResult{
 		at:               at,
 		numErrors:        0,
-		checkHealthyness: make(map[string]error),
+		checkResults:     make(map[string]checkResult),
+		startupCompleted: true,
 	}
 
 	for _, check := range m.healthChecks {
-		name := check.String()
-		err := check.IsHealthy()
-		result.checkHealthyness[name] = err
-		logEvent := m.logger.Debug()
-		if err != nil {
-			result.numErrors++
-			logEvent = m.logger.Error()
+		if check.probes.Has(Startup) && !check.passedOnce {
+			result.startupCompleted = false
 		}
-		logEvent.Err(err).
-			// don't propagate errors to alerting
-			Bool("no_alert", true).
-			Msgf("Check - '%s'", name)
-	}
 
-	if m.onCheckCallback != nil {
-		healthy := true
-		if result.numErrors > 0 {
-			healthy = false
+		// checks that were registered during the evaluation have no result yet
+		if check.lastEvaluatedAt.IsZero() {
+			continue
 		}
-		m.onCheckCallback(healthy, result.numErrors)
-	}
 
+		err := check.err()
+		if check.isStale(at) {
+			err = fmt.Errorf("stale result, last evaluation was %s ago", at.Sub(check.lastEvaluatedAt))
+		}
+
+		result.checkResults[check.name] = checkResult{
+			err:         err,
+			probes:      check.probes,
+			severity:    check.severity,
+			evaluatedAt: check.lastEvaluatedAt,
+			duration:    check.lastDuration,
+			details:     check.lastDetails,
+			children:    check.lastChildren,
+			degraded:    check.isDegraded(),
+			degradedErr: check.lastDegradedErr,
+			message:     check.lastMessage,
+
+			nextEvaluationAt: check.nextEvaluationAt(m.checkInterval),
+			backoffDelay:     check.backoffDelay,
+
+			consecutiveFailures:  check.consecutiveFailures,
+			consecutiveSuccesses: check.consecutiveSuccesses,
+		}
+		if err != nil {
+			result.numErrors++
+		}
+	}
 	return result
 }
 
-// Register can be used to register a Check
+// Register can be used to register a Check.
+// The checks are assigned to the Liveness and the Readiness probe.
+// Use RegisterCheck to assign a Check to specific probes.
 func (m *Monitor) Register(checks ...Check) error {
 
+	toRegister := make([]*registeredCheck, 0, len(checks))
 	for _, check := range checks {
 		if check == nil {
 			return fmt.Errorf("Unable to register a check that is nil")
 		}
-		if len(strings.TrimSpace(check.String())) == 0 {
-			return fmt.Errorf("Unable to register a check without a name")
+		rc, err := newRegisteredCheck(AdaptCheck(check))
+		if err != nil {
+			return err
 		}
+		toRegister = append(toRegister, rc)
+	}
+
+	return m.add(toRegister...)
+}
+
+// RegisterCheck can be used to register a Check with additional options.
+//
+//	// the check is only evaluated by the Readiness probe
+//	monitor.RegisterCheck(shutdownHandler, health.ForProbes(health.Readiness))
+func (m *Monitor) RegisterCheck(check Check, options ...CheckOption) error {
+	if check == nil {
+		return fmt.Errorf("Unable to register a check that is nil")
+	}
+	return m.RegisterContextCheck(AdaptCheck(check), options...)
+}
+
+// RegisterResultCheck can be used to register a ResultCheck with additional options.
+// The message and details of its Result are reported by the health endpoint and kept in the history.
+func (m *Monitor) RegisterResultCheck(check ResultCheck, options ...CheckOption) error {
+	if check == nil {
+		return fmt.Errorf("Unable to register a check that is nil")
+	}
+	return m.RegisterContextCheck(AdaptResultCheck(check), options...)
+}
+
+// RegisterContextCheck can be used to register a ContextCheck with additional options.
+func (m *Monitor) RegisterContextCheck(check ContextCheck, options ...CheckOption) error {
+	rc, err := newRegisteredCheck(check, options...)
+	if err != nil {
+		return err
 	}
 
+	return m.add(rc)
+}
+
+// add adds the given checks and informs the monitor loop about the new checks
+func (m *Monitor) add(checks ...*registeredCheck) error {
 	m.mux.Lock()
 	defer m.mux.Unlock()
+
+	names := make(map[string]bool, len(m.healthChecks)+len(checks))
+	for _, check := range m.healthChecks {
+		names[check.name] = true
+	}
+	for _, check := range checks {
+		if names[check.name] {
+			return fmt.Errorf("Unable to register check '%s', a check with this name is already registered", check.name)
+		}
+		names[check.name] = true
+	}
+
+	for _, check := range checks {
+		check.history = newCheckHistory(m.resultHistorySize, m.transitionHistorySize)
+	}
 	m.healthChecks = append(m.healthChecks, checks...)
+
+	m.reschedule()
+	return nil
+}
+
+// Unregister removes the check with the given name.
+// An error is returned in case no check with this name is registered.
+func (m *Monitor) Unregister(name string) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	idx := m.indexOf(name)
+	if idx < 0 {
+		return fmt.Errorf("Unable to unregister check '%s', no check with this name is registered", name)
+	}
+
+	m.healthChecks = append(m.healthChecks[:idx], m.healthChecks[idx+1:]...)
+	m.forget(name)
+
+	m.reschedule()
+	return nil
+}
+
+// Replace replaces the check with the given name by the given Check.
+// The new check starts without results and history and is evaluated right away by a running Monitor.
+func (m *Monitor) Replace(name string, check Check, options ...CheckOption) error {
+	if check == nil {
+		return fmt.Errorf("Unable to replace check '%s' by a check that is nil", name)
+	}
+	return m.ReplaceContextCheck(name, AdaptCheck(check), options...)
+}
+
+// ReplaceContextCheck replaces the check with the given name by the given ContextCheck.
+func (m *Monitor) ReplaceContextCheck(name string, check ContextCheck, options ...CheckOption) error {
+	rc, err := newRegisteredCheck(check, options...)
+	if err != nil {
+		return err
+	}
+	rc.history = newCheckHistory(m.resultHistorySize, m.transitionHistorySize)
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	idx := m.indexOf(name)
+	if idx < 0 {
+		return fmt.Errorf("Unable to replace check '%s', no check with this name is registered", name)
+	}
+	if rc.name != name && m.indexOf(rc.name) >= 0 {
+		return fmt.Errorf("Unable to replace check '%s' by '%s', a check with this name is already registered", name, rc.name)
+	}
+
+	m.healthChecks[idx] = rc
+	m.forget(name)
+
+	m.reschedule()
 	return nil
 }
+
+// indexOf returns the index of the check with the given name, -1 in case there is no such check.
+// m.mux has to be held by the caller.
+func (m *Monitor) indexOf(name string) int {
+	for i, check := range m.healthChecks {
+		if check.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// forget removes the result of the check with the given name from the latest result.
+// m.mux has to be held by the caller.
+func (m *Monitor) forget(name string) {
+	latest := m.latestCheckResult.Load().(checkEvaluationResult)
+	if _, ok := latest.checkResults[name]; !ok {
+		return
+	}
+
+	result := latest
+	result.numErrors = 0
+	result.checkResults = make(map[string]checkResult, len(latest.checkResults))
+	for checkName, cr := range latest.checkResults {
+		if checkName == name {
+			continue
+		}
+		result.checkResults[checkName] = cr
+		if cr.err != nil {
+			result.numErrors++
+		}
+	}
+	m.latestCheckResult.Store(result)
+}
+
+// reschedule informs the monitor loop that the schedule of the checks has changed
+func (m *Monitor) reschedule() {
+	select {
+	case m.rescheduleChan <- struct{}{}:
+	default:
+		// a reschedule is already pending
+	}
+}
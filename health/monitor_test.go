@@ -1,13 +1,18 @@
 package health
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	mock_health "github.com/ThomasObenaus/go-base/test/mocks/health"
 	"github.com/golang/mock/gomock"
+	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -32,13 +37,13 @@ func Test_EvaluateChecks(t *testing.T) {
 	defer mockCtrl.Finish()
 	nameCheck1 := "check1-healthy"
 	check1 := mock_health.NewMockCheck(mockCtrl)
-	check1.EXPECT().String().Return(nameCheck1).Times(2)
+	check1.EXPECT().String().Return(nameCheck1)
 	check1.EXPECT().IsHealthy().Return(nil)
 
 	nameCheck2 := "check2-unhealthy"
 	errCheck2 := fmt.Errorf("could not connect")
 	check2 := mock_health.NewMockCheck(mockCtrl)
-	check2.EXPECT().String().Return(nameCheck2).Times(2)
+	check2.EXPECT().String().Return(nameCheck2)
 	check2.EXPECT().IsHealthy().Return(errCheck2)
 
 	monitor, err := NewMonitor()
@@ -56,10 +61,10 @@ func Test_EvaluateChecks(t *testing.T) {
 	// THEN
 	assert.Equal(t, now, checkResult.at)
 	assert.Equal(t, uint(1), checkResult.numErrors)
-	assert.Len(t, checkResult.checkHealthyness, 2)
-	assert.Nil(t, checkResult.checkHealthyness[nameCheck1])
-	assert.NotNil(t, checkResult.checkHealthyness[nameCheck2])
-	assert.Equal(t, errCheck2, checkResult.checkHealthyness[nameCheck2])
+	assert.Len(t, checkResult.checkResults, 2)
+	assert.Nil(t, checkResult.checkResults[nameCheck1].err)
+	assert.NotNil(t, checkResult.checkResults[nameCheck2].err)
+	assert.Equal(t, errCheck2, checkResult.checkResults[nameCheck2].err)
 }
 
 func Test_ShouldRegister(t *testing.T) {
@@ -134,19 +139,486 @@ func Test_OnCheck(t *testing.T) {
 	assert.Equal(t, uint(1), numErrors)
 }
 
-func TestRunJoinStop(t *testing.T) {
+func Test_OnCheckStatus(t *testing.T) {
+
+	// GIVEN
+	var status Status
+	healthy := false
+	numErrors := uint(0)
+	monitor, err := NewMonitor(
+		OnCheck(func(h bool, n uint) {
+			healthy = h
+		}),
+		OnCheckStatus(func(s Status, n uint) {
+			status = s
+			numErrors = n
+		}),
+	)
+	require.NotNil(t, monitor)
+	require.NoError(t, err)
+	check, err := NewSimpleCheck("cache", func() error {
+		return fmt.Errorf("ERROR")
+	})
+	require.NoError(t, err)
+	err = monitor.RegisterCheck(check, WithSeverity(NonCritical))
+	require.NoError(t, err)
+
+	// WHEN
+	now := time.Now()
+	monitor.evaluateChecks(now)
+
+	// THEN
+	assert.True(t, healthy)
+	assert.Equal(t, StatusDegraded, status)
+	assert.Equal(t, uint(1), numErrors)
+}
+
+func Test_ShouldRegisterCheckForProbes(t *testing.T) {
 
 	// GIVEN
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	check1 := mock_health.NewMockCheck(mockCtrl)
 	monitor, err := NewMonitor()
+	require.NoError(t, err)
 	require.NotNil(t, monitor)
+
+	// WHEN
+	check1.EXPECT().String().Return("check1")
+	err = monitor.RegisterCheck(check1, ForProbes(Readiness, Startup))
+
+	// THEN
+	assert.NoError(t, err)
+	require.Len(t, monitor.healthChecks, 1)
+	assert.Equal(t, Readiness|Startup, monitor.healthChecks[0].probes)
+
+	// WHEN
+	check1.EXPECT().String().Return("check1")
+	err = monitor.RegisterCheck(check1, ForProbes())
+
+	// THEN
+	assert.Error(t, err)
+	assert.Len(t, monitor.healthChecks, 1)
+}
+
+func Test_StartupCompletedOnceEachStartupCheckPassed(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	var startupErr error = fmt.Errorf("still warming up")
+	startupCheck, err := NewSimpleCheck("warmup", func() error {
+		return startupErr
+	})
+	require.NoError(t, err)
+	err = monitor.RegisterCheck(startupCheck, ForProbes(Startup))
 	require.NoError(t, err)
 
-	monitor.Start()
+	// WHEN
+	now := time.Now()
+	checkResult := monitor.evaluateChecks(now)
+
+	// THEN
+	assert.False(t, checkResult.startupCompleted)
+
+	// WHEN
+	startupErr = nil
+	now = now.Add(monitor.checkInterval)
+	checkResult = monitor.evaluateChecks(now)
+
+	// THEN
+	assert.True(t, checkResult.startupCompleted)
+
+	// WHEN - failing again after the startup has been completed
+	startupErr = fmt.Errorf("broken")
+	now = now.Add(monitor.checkInterval)
+	checkResult = monitor.evaluateChecks(now)
+
+	// THEN
+	assert.True(t, checkResult.startupCompleted)
+}
+
+func Test_EvaluateChecksShouldAbandonCheckOnTimeout(t *testing.T) {
+
+	// GIVEN
+	release := make(chan struct{})
+	defer close(release)
+	hangingCheck, err := NewSimpleCheck("hanging", func() error {
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	contextCheck, err := NewSimpleContextCheck("context-aware", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	require.NoError(t, err)
+	healthyCheck, err := NewSimpleCheck("healthy", func() error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	err = monitor.RegisterCheck(hangingCheck, WithTimeout(time.Millisecond*10))
+	require.NoError(t, err)
+	err = monitor.RegisterContextCheck(contextCheck, WithTimeout(time.Millisecond*10))
+	require.NoError(t, err)
+	err = monitor.Register(healthyCheck)
+	require.NoError(t, err)
+
+	// WHEN
+	now := time.Now()
+	checkResult := monitor.evaluateChecks(now)
+
+	// THEN
+	assert.Equal(t, uint(2), checkResult.numErrors)
+	assert.EqualError(t, checkResult.checkResults["hanging"].err, "timeout after 10ms")
+	assert.Error(t, checkResult.checkResults["context-aware"].err)
+	assert.NoError(t, checkResult.checkResults["healthy"].err)
+
+	// WHEN - the hanging check did not return yet
+	checkResult = monitor.evaluateChecks(now.Add(monitor.checkInterval))
+
+	// THEN
+	assert.Equal(t, uint(2), checkResult.numErrors)
+	assert.EqualError(t, checkResult.checkResults["hanging"].err, "timeout after 10ms (previous evaluation still in progress)")
+	assert.NoError(t, checkResult.checkResults["healthy"].err)
+}
+
+func Test_ShouldNotRegisterWithInvalidTimeout(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	check, err := NewSimpleCheck("check1", func() error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	// WHEN
+	err = monitor.RegisterCheck(check, WithTimeout(0))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Len(t, monitor.healthChecks, 0)
+}
+
+func Test_EvaluateChecksInTheirOwnInterval(t *testing.T) {
+
+	// GIVEN
+	var numFastEvaluations, numSlowEvaluations int
+	fastCheck, err := NewSimpleCheck("fast", func() error {
+		numFastEvaluations++
+		return nil
+	})
+	require.NoError(t, err)
+	slowCheck, err := NewSimpleCheck("slow", func() error {
+		numSlowEvaluations++
+		return fmt.Errorf("schema mismatch")
+	})
+	require.NoError(t, err)
+
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	err = monitor.RegisterCheck(fastCheck, WithInterval(time.Second))
+	require.NoError(t, err)
+	err = monitor.RegisterCheck(slowCheck, WithInterval(time.Minute))
+	require.NoError(t, err)
+
+	// WHEN
 	start := time.Now()
+	var checkResult checkEvaluationResult
+	for i := 0; i <= 90; i++ {
+		checkResult = monitor.evaluateChecks(start.Add(time.Second * time.Duration(i)))
+	}
+
+	// THEN
+	assert.Equal(t, 91, numFastEvaluations)
+	assert.Equal(t, 2, numSlowEvaluations)
+	assert.Equal(t, uint(1), checkResult.numErrors)
+	assert.Equal(t, start.Add(time.Second*90), checkResult.checkResults["fast"].evaluatedAt)
+	assert.Equal(t, start.Add(time.Minute), checkResult.checkResults["slow"].evaluatedAt)
+	assert.EqualError(t, checkResult.checkResults["slow"].err, "schema mismatch")
+	assert.Equal(t, time.Millisecond*500, monitor.nextEvaluationIn(start.Add(time.Millisecond*90500)))
+}
+
+func Test_MonitorSchedulesChecksInTheirOwnInterval(t *testing.T) {
+
+	// GIVEN
+	var numEvaluations int32
+	check, err := NewSimpleCheck("check1", func() error {
+		atomic.AddInt32(&numEvaluations, 1)
+		return nil
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+
+	// WHEN
+	monitor.Start()
+	err = monitor.RegisterCheck(check, WithInterval(time.Millisecond*10))
+	require.NoError(t, err)
+
+	// THEN
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&numEvaluations) >= 3
+	}, time.Second, time.Millisecond*5)
 	monitor.Stop()
 	monitor.Join()
+}
+
+func Test_EvaluateChecksShouldReportStaleResults(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("check1", func() error {
+		return nil
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	err = monitor.RegisterCheck(check, WithInterval(time.Second*10), WithStaleness(time.Second*30))
+	require.NoError(t, err)
+
+	// WHEN
+	start := time.Now()
+	checkResult := monitor.evaluateChecks(start)
+
+	// THEN
+	assert.Equal(t, uint(0), checkResult.numErrors)
+
+	// WHEN
+	checkResult = monitor.latestResult(start.Add(time.Second * 31))
+
+	// THEN
+	assert.Equal(t, uint(1), checkResult.numErrors)
+	assert.EqualError(t, checkResult.checkResults["check1"].err, "stale result, last evaluation was 31s ago")
+}
+
+func Test_ShouldNotRegisterWithInvalidStaleness(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	check, err := NewSimpleCheck("check1", func() error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	// WHEN
+	err = monitor.RegisterCheck(check, WithInterval(time.Minute), WithStaleness(time.Second))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Len(t, monitor.healthChecks, 0)
+}
+
+func newSleepingCheck(t *testing.T, name string, sleep time.Duration, running, maxRunning *int32) Check {
+	check, err := NewSimpleCheck(name, func() error {
+		current := atomic.AddInt32(running, 1)
+		defer atomic.AddInt32(running, -1)
+		for {
+			highest := atomic.LoadInt32(maxRunning)
+			if current <= highest || atomic.CompareAndSwapInt32(maxRunning, highest, current) {
+				break
+			}
+		}
+		time.Sleep(sleep)
+		return nil
+	})
+	require.NoError(t, err)
+	return check
+}
+
+func Test_EvaluateChecksConcurrently(t *testing.T) {
+
+	// GIVEN
+	var running, maxRunning int32
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	for i := 0; i < 10; i++ {
+		err = monitor.Register(newSleepingCheck(t, fmt.Sprintf("check%d", i), time.Millisecond*100, &running, &maxRunning))
+		require.NoError(t, err)
+	}
+	err = monitor.Register(newSleepingCheck(t, "slowest", time.Millisecond*300, &running, &maxRunning))
+	require.NoError(t, err)
+
+	// WHEN
+	start := time.Now()
+	checkResult := monitor.evaluateChecks(start)
+	roundTime := time.Since(start)
+
+	// THEN
+	assert.Equal(t, uint(0), checkResult.numErrors)
+	assert.Len(t, checkResult.checkResults, 11)
+	assert.Equal(t, int32(11), atomic.LoadInt32(&maxRunning))
+	// the round is bounded by the slowest check and not by the sum of all checks (1300ms)
+	assert.GreaterOrEqual(t, roundTime, time.Millisecond*300)
+	assert.Less(t, roundTime, time.Millisecond*600)
+}
+
+func Test_EvaluateChecksConcurrentlyWithLimit(t *testing.T) {
+
+	// GIVEN
+	var running, maxRunning int32
+	monitor, err := NewMonitor(WithMaxConcurrentChecks(2))
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	for i := 0; i < 4; i++ {
+		err = monitor.Register(newSleepingCheck(t, fmt.Sprintf("check%d", i), time.Millisecond*100, &running, &maxRunning))
+		require.NoError(t, err)
+	}
+
+	// WHEN
+	start := time.Now()
+	checkResult := monitor.evaluateChecks(start)
+	roundTime := time.Since(start)
+
+	// THEN
+	assert.Equal(t, uint(0), checkResult.numErrors)
+	assert.Len(t, checkResult.checkResults, 4)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&maxRunning))
+	assert.GreaterOrEqual(t, roundTime, time.Millisecond*200)
+	assert.Less(t, roundTime, time.Millisecond*400)
+}
+
+func Test_EvaluateChecksWithLimitShouldBeBoundByTheTimeout(t *testing.T) {
+
+	// GIVEN
+	release := make(chan struct{})
+	defer close(release)
+	monitor, err := NewMonitor(WithMaxConcurrentChecks(2))
+	require.NoError(t, err)
+	require.NotNil(t, monitor)
+	for i := 0; i < 6; i++ {
+		hangingCheck, err := NewSimpleCheck(fmt.Sprintf("hanging%d", i), func() error {
+			<-release
+			return nil
+		})
+		require.NoError(t, err)
+		err = monitor.RegisterCheck(hangingCheck, WithTimeout(time.Millisecond*100))
+		require.NoError(t, err)
+	}
+
+	// WHEN
+	start := time.Now()
+	checkResult := monitor.evaluateChecks(start)
+	roundTime := time.Since(start)
+
+	// THEN
+	// the checks waiting for a free slot time out together with the ones occupying the slots
+	assert.Equal(t, uint(6), checkResult.numErrors)
+	for name, result := range checkResult.checkResults {
+		require.Error(t, result.err, name)
+		assert.Contains(t, result.err.Error(), "timeout after 100ms", name)
+	}
+	assert.Less(t, roundTime, time.Millisecond*250)
+}
+
+func TestRunJoinStop(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NotNil(t, monitor)
+	require.NoError(t, err)
+
+	// WHEN
+	monitor.Start()
+	err = monitor.Stop()
+	monitor.Join()
 
-	assert.WithinDuration(t, start.Add(time.Millisecond*500), time.Now(), time.Second*1)
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, stateStopped, monitor.state)
+}
+
+func Test_StopShouldAbortRunningEvaluations(t *testing.T) {
+
+	// GIVEN
+	started := make(chan struct{})
+	var aborted atomic.Bool
+	blockingCheck, err := NewSimpleContextCheck("blocking", func(ctx context.Context) error {
+		close(started)
+		select {
+		case <-ctx.Done():
+			aborted.Store(true)
+			return ctx.Err()
+		case <-time.After(time.Second * 5):
+			return nil
+		}
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterContextCheck(blockingCheck))
+	monitor.Start()
+	<-started
+
+	// WHEN
+	begin := time.Now()
+	err = monitor.Stop()
+	monitor.Join()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(begin), time.Second)
+	assert.Eventually(t, aborted.Load, time.Second, time.Millisecond*5)
+	// the results of the aborted round are discarded
+	assert.Empty(t, monitor.latestCheckResult.Load().(checkEvaluationResult).checkResults)
+}
+
+func Test_OverlappingEvaluationsShouldNotRecordFailures(t *testing.T) {
+
+	// GIVEN
+	var evaluations atomic.Int32
+	slowCheck, err := NewSimpleCheck("slow", func() error {
+		evaluations.Add(1)
+		time.Sleep(time.Millisecond * 200)
+		return nil
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(slowCheck))
+
+	// WHEN
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			monitor.EvaluateNow()
+		}()
+		// the second call overlaps the evaluation started by the first one
+		require.Eventually(t, func() bool { return evaluations.Load() == 1 }, time.Second, time.Millisecond)
+	}
+	wg.Wait()
+
+	// THEN
+	// the second call waited for the evaluation that was already in progress
+	assert.Equal(t, int32(1), evaluations.Load())
+	result := monitor.latestCheckResult.Load().(checkEvaluationResult)
+	assert.NoError(t, result.checkResults["slow"].err)
+	assert.Equal(t, uint(0), result.checkResults["slow"].consecutiveFailures)
+	assert.Equal(t, uint(1), result.checkResults["slow"].consecutiveSuccesses)
+}
+
+func Test_NewMonitorShouldFailWithoutClock(t *testing.T) {
+
+	// WHEN
+	monitor, err := NewMonitor(WithClock(nil))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, monitor)
 }
 
 func ExampleNewMonitor() {
@@ -166,3 +638,268 @@ func ExampleNewMonitor() {
 
 	monitor.Stop()
 }
+
+func Test_EvaluateChecksShouldRecoverPanickingCheck(t *testing.T) {
+
+	// GIVEN
+	logs := bytes.Buffer{}
+	panicking, err := NewSimpleCheck("panicking", func() error { panic("boom") })
+	require.NoError(t, err)
+	healthy, err := NewSimpleCheck("healthy", func() error { return nil })
+	require.NoError(t, err)
+
+	monitor, err := NewMonitor(WithLogger(zerolog.New(&logs)))
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(panicking, healthy))
+
+	// WHEN
+	result := monitor.evaluateChecks(time.Now())
+
+	// THEN
+	assert.Equal(t, uint(1), result.numErrors)
+	assert.EqualError(t, result.checkResults["panicking"].err, "panic: boom")
+	assert.NoError(t, result.checkResults["healthy"].err)
+	assert.Contains(t, logs.String(), "Check - 'panicking' panicked: boom")
+	assert.Contains(t, logs.String(), `"stack":"goroutine`)
+}
+
+func Test_StartAndStopShouldBeIdempotent(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN
+	assert.NoError(t, monitor.Stop())
+	monitor.Start()
+	monitor.Start()
+
+	// THEN
+	assert.Equal(t, stateRunning, monitor.state)
+
+	// WHEN
+	assert.NoError(t, monitor.Stop())
+	assert.NoError(t, monitor.Stop())
+	monitor.Join()
+
+	// THEN
+	assert.Equal(t, stateStopped, monitor.state)
+}
+
+func Test_MonitorShouldBeRestartable(t *testing.T) {
+
+	// GIVEN
+	var numEvaluations int32
+	check, err := NewSimpleCheck("check1", func() error {
+		atomic.AddInt32(&numEvaluations, 1)
+		return nil
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(check, WithInterval(time.Millisecond*10)))
+
+	monitor.Start()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&numEvaluations) >= 1 }, time.Second, time.Millisecond*5)
+	require.NoError(t, monitor.Stop())
+	monitor.Join()
+	evaluationsBeforeRestart := atomic.LoadInt32(&numEvaluations)
+
+	// WHEN
+	monitor.Start()
+
+	// THEN
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&numEvaluations) > evaluationsBeforeRestart
+	}, time.Second, time.Millisecond*5)
+	assert.NoError(t, monitor.Stop())
+	monitor.Join()
+}
+
+func Test_RunShouldReturnWhenContextIsDone(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+
+	// WHEN
+	go func() {
+		runErr <- monitor.Run(ctx)
+	}()
+
+	// THEN
+	require.Eventually(t, func() bool {
+		monitor.lifecycleMux.Lock()
+		defer monitor.lifecycleMux.Unlock()
+		return monitor.state == stateRunning
+	}, time.Second, time.Millisecond*5)
+	assert.Error(t, monitor.Run(context.Background()))
+
+	// WHEN
+	cancel()
+
+	// THEN
+	assert.NoError(t, <-runErr)
+	monitor.Join()
+	assert.Equal(t, stateStopped, monitor.state)
+}
+
+func Test_ConcurrentLifecycleCalls(t *testing.T) {
+
+	// GIVEN
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			check, err := NewSimpleCheck(fmt.Sprintf("check%d", i), func() error { return nil })
+			assert.NoError(t, err)
+			assert.NoError(t, monitor.RegisterCheck(check, WithInterval(time.Millisecond)))
+			monitor.Start()
+			assert.NoError(t, monitor.Stop())
+			assert.NoError(t, monitor.Unregister(check.String()))
+		}(i)
+	}
+	wg.Wait()
+	monitor.Join()
+
+	// THEN
+	assert.Equal(t, stateStopped, monitor.state)
+	assert.Empty(t, monitor.healthChecks)
+}
+
+func Test_ShouldNotRegisterDuplicateNames(t *testing.T) {
+
+	// GIVEN
+	check1, err := NewSimpleCheck("check", func() error { return nil })
+	require.NoError(t, err)
+	check2, err := NewSimpleCheck("check", func() error { return nil })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.Error(t, monitor.Register(check1, check2))
+	assert.Empty(t, monitor.healthChecks)
+	assert.NoError(t, monitor.Register(check1))
+	assert.Error(t, monitor.RegisterCheck(check2))
+	assert.Len(t, monitor.healthChecks, 1)
+}
+
+func Test_Unregister(t *testing.T) {
+
+	// GIVEN
+	check1, err := NewSimpleCheck("check1", func() error { return fmt.Errorf("failed") })
+	require.NoError(t, err)
+	check2, err := NewSimpleCheck("check2", func() error { return nil })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(check1, check2))
+	monitor.evaluateChecks(time.Now())
+
+	// WHEN
+	err = monitor.Unregister("check1")
+
+	// THEN
+	assert.NoError(t, err)
+	require.Len(t, monitor.healthChecks, 1)
+	assert.Equal(t, "check2", monitor.healthChecks[0].name)
+	latest := monitor.latestCheckResult.Load().(checkEvaluationResult)
+	assert.NotContains(t, latest.checkResults, "check1")
+	assert.Equal(t, uint(0), latest.numErrors)
+	assert.Error(t, monitor.Unregister("check1"))
+}
+
+func Test_Replace(t *testing.T) {
+
+	// GIVEN
+	failing, err := NewSimpleCheck("db", func() error { return fmt.Errorf("failed") })
+	require.NoError(t, err)
+	healthy, err := NewSimpleCheck("db", func() error { return nil })
+	require.NoError(t, err)
+	other, err := NewSimpleCheck("cache", func() error { return nil })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(failing, other))
+	now := time.Now()
+	monitor.evaluateChecks(now)
+
+	// WHEN
+	err = monitor.Replace("db", healthy, ForProbes(Readiness))
+
+	// THEN
+	assert.NoError(t, err)
+	require.Len(t, monitor.healthChecks, 2)
+	assert.Equal(t, Readiness, monitor.healthChecks[0].probes)
+	result := monitor.evaluateChecks(now.Add(time.Second))
+	assert.NoError(t, result.checkResults["db"].err)
+
+	// WHEN + THEN
+	assert.Error(t, monitor.Replace("unknown", healthy))
+	assert.Error(t, monitor.Replace("db", other))
+	assert.Error(t, monitor.Replace("db", nil))
+}
+
+func Test_EvaluateChecksShouldReportSlowChecks(t *testing.T) {
+
+	// GIVEN
+	logs := bytes.Buffer{}
+	clock := &fixedClock{now: time.Now()}
+	slowDB, err := NewSimpleCheck("db", func() error {
+		clock.now = clock.now.Add(time.Millisecond * 250)
+		return nil
+	})
+	require.NoError(t, err)
+	slowCache, err := NewSimpleCheck("cache", func() error {
+		clock.now = clock.now.Add(time.Millisecond * 250)
+		return nil
+	})
+	require.NoError(t, err)
+	monitor, err := NewMonitor(WithClock(clock), WithLogger(zerolog.New(&logs)), WithMaxConcurrentChecks(1))
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(slowDB, WithLatencyBudget(time.Millisecond*100, true)))
+	require.NoError(t, monitor.RegisterCheck(slowCache, WithLatencyBudget(time.Millisecond*100, false)))
+
+	// WHEN
+	result := monitor.evaluateChecks(clock.now)
+	_, response := checkEvaluationResultToResponse(result, result.at, time.Second*30)
+
+	// THEN
+	assert.Equal(t, StatusDegraded, result.status())
+	assert.Equal(t, time.Millisecond*250, result.checkResults["db"].duration)
+	assert.True(t, result.checkResults["db"].degraded)
+	assert.False(t, result.checkResults["cache"].degraded)
+	assert.Contains(t, logs.String(), "Check - 'db' exceeded its latency budget")
+	assert.Contains(t, logs.String(), "Check - 'cache' exceeded its latency budget")
+	for _, check := range response.Checks {
+		assert.Equal(t, float64(250), check.DurationMS)
+		if check.Name == "db" {
+			assert.Equal(t, "degraded", check.Status)
+		} else {
+			assert.Equal(t, "healthy", check.Status)
+		}
+	}
+}
+
+func Test_ShouldNotRegisterWithInvalidLatencyBudget(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("check1", func() error { return nil })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN
+	err = monitor.RegisterCheck(check, WithLatencyBudget(-time.Second, false))
+
+	// THEN
+	assert.Error(t, err)
+}
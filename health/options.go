@@ -1,6 +1,10 @@
 package health
 
-import "github.com/rs/zerolog"
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
 
 // Option represents an option for the Monitor
 type Option func(m *Monitor)
@@ -12,7 +16,50 @@ func WithLogger(logger zerolog.Logger) Option {
 	}
 }
 
-// OnCheckFun called each time the monitor evaluates the checks, hence it can provide the state at this point in time
+// WithClock specifies the Clock the Monitor obtains the current time and its timers from (default the wall clock)
+func WithClock(clock Clock) Option {
+	return func(m *Monitor) {
+		m.clock = clock
+	}
+}
+
+// WithStatusFile specifies a file the Monitor writes the status to after each evaluation of a Check (in the format of the health endpoint).
+// This way the status can be obtained without HTTP, e.g. by the healthcheck probe of a container (see package healthcheck).
+func WithStatusFile(path string) Option {
+	return func(m *Monitor) {
+		m.statusFile = path
+	}
+}
+
+// WithRestrictedDetails restricts the results of the individual checks (including their errors) to callers that are
+// authorized by one of the given authorizers (e.g. TokenAuthorizer or CIDRAuthorizer). All other callers get only the overall status
+// (the metrics endpoint reports only the overall metrics to them).
+// The history endpoint is not available for them at all.
+func WithRestrictedDetails(authorizers ...Authorizer) Option {
+	return func(m *Monitor) {
+		m.restrictDetails = true
+		m.authorizers = authorizers
+	}
+}
+
+// WithMaintenanceAuthorizers specifies the authorizers for the MaintenanceHandler, only callers that are authorized by one of them
+// may enter or leave the maintenance. Per default no caller is authorized, i.e. the maintenance can only be changed programmatically.
+func WithMaintenanceAuthorizers(authorizers ...Authorizer) Option {
+	return func(m *Monitor) {
+		m.maintenanceAuthorizers = authorizers
+	}
+}
+
+// WithErrorSanitizer specifies a function that sanitizes the error messages (and the messages of a Result) of the checks before they are reported
+// by the endpoints or written to the status file (e.g. to remove hostnames or user names).
+func WithErrorSanitizer(sanitizer ErrorSanitizer) Option {
+	return func(m *Monitor) {
+		m.errorSanitizer = sanitizer
+	}
+}
+
+// OnCheckFun called each time the monitor evaluates the checks, hence it can provide the state at this point in time.
+// The service is regarded as healthy as long as its status is not StatusUnhealthy (i.e. only non-critical checks failed).
 type OnCheckFun func(healthy bool, numErrors uint)
 
 // OnCheck sets the callback that is called each time the monitor evaluates the checks
@@ -21,3 +68,113 @@ func OnCheck(fun OnCheckFun) Option {
 		m.onCheckCallback = fun
 	}
 }
+
+// OnCheckStatusFun called each time the monitor evaluates the checks, hence it can provide the status at this point in time
+type OnCheckStatusFun func(status Status, numErrors uint)
+
+// OnCheckStatus sets the callback that is called each time the monitor evaluates the checks
+func OnCheckStatus(fun OnCheckStatusFun) Option {
+	return func(m *Monitor) {
+		m.onCheckStatusCallback = fun
+	}
+}
+
+// CheckOption represents an option for a Check that is registered at the Monitor
+type CheckOption func(c *registeredCheck)
+
+// ForProbes assigns the Check to the given probes (i.e. Liveness, Readiness and/ or Startup).
+// Per default a Check is assigned to the Liveness and the Readiness probe.
+func ForProbes(probes ...Probe) CheckOption {
+	return func(c *registeredCheck) {
+		c.probes = 0
+		for _, probe := range probes {
+			c.probes |= probe
+		}
+	}
+}
+
+// WithTimeout specifies the time the evaluation of the Check may take at most (default 10s).
+// In case the timeout is exceeded the Check is reported as unhealthy and the evaluation of the other checks continues.
+// A ContextCheck is informed about the timeout through its context.
+// Each Check is evaluated on its own, hence a slow Check does not delay the evaluation of the other ones.
+func WithTimeout(timeout time.Duration) CheckOption {
+	return func(c *registeredCheck) {
+		c.timeout = timeout
+	}
+}
+
+// WithMaxConcurrentChecks limits the number of checks that are evaluated concurrently.
+// Per default all checks that are due are evaluated concurrently, a check that exceeds the limit waits for a free slot.
+// The time spent waiting counts against the timeout of the check.
+func WithMaxConcurrentChecks(maxConcurrentChecks int) Option {
+	return func(m *Monitor) {
+		m.maxConcurrentChecks = maxConcurrentChecks
+	}
+}
+
+// WithInterval specifies the interval the Check is evaluated in.
+// Per default the Check is evaluated in the interval of the Monitor (5s).
+// In between the evaluations the latest result of the Check is reported.
+func WithInterval(interval time.Duration) CheckOption {
+	return func(c *registeredCheck) {
+		c.interval = interval
+	}
+}
+
+// WithStaleness specifies how old the latest result of the Check may be.
+// In case the latest result is older (e.g. because the evaluation hangs) the Check is reported as unhealthy.
+// Per default the result of a Check never gets stale.
+func WithStaleness(staleness time.Duration) CheckOption {
+	return func(c *registeredCheck) {
+		c.staleness = staleness
+	}
+}
+
+// WithSeverity specifies the severity of the Check.
+// Per default each Check is Critical, i.e. the service is regarded as unhealthy in case the Check fails.
+// A failing NonCritical Check only degrades the service.
+func WithSeverity(severity Severity) CheckOption {
+	return func(c *registeredCheck) {
+		c.severity = severity
+	}
+}
+
+// WithThresholds specifies how many consecutive failures are needed before a healthy Check is regarded as unhealthy
+// and how many consecutive successes are needed before an unhealthy Check is regarded as healthy again.
+// This dampens flapping checks. Per default both thresholds are 1.
+func WithThresholds(failureThreshold, successThreshold uint) CheckOption {
+	return func(c *registeredCheck) {
+		c.failureThreshold = failureThreshold
+		c.successThreshold = successThreshold
+	}
+}
+
+// WithLatencyBudget specifies the time the evaluation of the Check should take at most (0 means no budget).
+// A Check that exceeds its budget is logged as warning and in case degrade is true it is reported as degraded
+// (i.e. the overall status is at least StatusDegraded), even though it succeeded.
+func WithLatencyBudget(budget time.Duration, degrade bool) CheckOption {
+	return func(c *registeredCheck) {
+		c.latencyBudget = budget
+		c.degradeWhenSlow = degrade
+	}
+}
+
+// WithBackoff stretches the interval of the Check while it is failing, hence an expensive Check does not put load
+// on a dependency that is already struggling. After the n-th consecutive failure the Check is evaluated again after
+// initial*multiplier^(n-1) (at most max, but at least the interval of the Check), varied randomly by the given jitter (0-1).
+// The interval is reset as soon as the Check succeeds. In combination with WithStaleness the staleness has to be at
+// least max*(1+jitter), since the result of a Check that backs off is not evaluated again before.
+func WithBackoff(initial, max time.Duration, multiplier, jitter float64) CheckOption {
+	return func(c *registeredCheck) {
+		c.backoff = newBackoff(initial, max, multiplier, jitter)
+	}
+}
+
+// WithHistorySize specifies how many results and state transitions are kept per Check (default 20 each).
+// The history can be obtained via Monitor.CheckHistory or the Monitor.History endpoint.
+func WithHistorySize(resultHistorySize, transitionHistorySize int) Option {
+	return func(m *Monitor) {
+		m.resultHistorySize = resultHistorySize
+		m.transitionHistorySize = transitionHistorySize
+	}
+}
@@ -0,0 +1,24 @@
+package health
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// panicError is the error a check is regarded as failed with in case it panicked during its evaluation
+type panicError struct {
+	value interface{}
+	// the stack trace of the go-routine that panicked
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// recoverPanic has to be deferred, it turns a panic into a panicError that is assigned to err
+func recoverPanic(err *error) {
+	if r := recover(); r != nil {
+		*err = &panicError{value: r, stack: debug.Stack()}
+	}
+}
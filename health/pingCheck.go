@@ -0,0 +1,214 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultPingTimeout is the time a ping may take at most if no timeout was specified explicitly
+const defaultPingTimeout = time.Second * 5
+
+// Pinger is a client (e.g. of a database or message broker) whose connection can be checked by calling Ping
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// SimplePinger is a client whose connection can be checked by calling Ping, but which is not aware of a context
+type SimplePinger interface {
+	Ping() error
+}
+
+// PingOption represents an option for the checks created by NewSQLCheck, NewPingCheck and NewSimplePingCheck
+type PingOption func(c *pingCheck) error
+
+// WithPingTimeout specifies the time the ping (including the validation) may take at most (default 5s)
+func WithPingTimeout(timeout time.Duration) PingOption {
+	return func(c *pingCheck) error {
+		if timeout <= 0 {
+			return fmt.Errorf("Invalid timeout %s", timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithValidationQuery specifies a query that is executed after a successful ping (e.g. "SELECT 1").
+// The check is unhealthy in case the query fails. This is only supported by NewSQLCheck.
+func WithValidationQuery(query string) PingOption {
+	return func(c *pingCheck) error {
+		if c.db == nil {
+			return fmt.Errorf("A validation query is only supported for a *sql.DB")
+		}
+		c.validate = func(ctx context.Context) error {
+			rows, err := c.db.QueryContext(ctx, query)
+			if err != nil {
+				return fmt.Errorf("Validation query failed: %w", err)
+			}
+			defer rows.Close()
+
+			// an error might only show up while the rows are read
+			for rows.Next() {
+			}
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("Validation query failed: %w", err)
+			}
+			return rows.Close()
+		}
+		return nil
+	}
+}
+
+// WithValidation specifies a function that is called after a successful ping.
+// The check is unhealthy in case the function returns an error.
+func WithValidation(validate func(ctx context.Context) error) PingOption {
+	return func(c *pingCheck) error {
+		c.validate = validate
+		return nil
+	}
+}
+
+// WithPoolStats reports the statistics of the connection pool (open, in use and idle connections, wait count and duration)
+// as details of the check. This is only supported by NewSQLCheck.
+func WithPoolStats() PingOption {
+	return func(c *pingCheck) error {
+		if c.db == nil {
+			return fmt.Errorf("Pool statistics are only supported for a *sql.DB")
+		}
+		c.poolStats = true
+		return nil
+	}
+}
+
+// NewSQLCheck creates a Check that is healthy as long as the given database can be pinged within the timeout.
+func NewSQLCheck(name string, db *sql.DB, options ...PingOption) (Check, error) {
+	if db == nil {
+		return nil, fmt.Errorf("Can't create a Check whose db is nil")
+	}
+	return newPingCheck(name, sqlPinger{db: db}, db, options...)
+}
+
+type sqlPinger struct {
+	db *sql.DB
+}
+
+func (p sqlPinger) Ping(ctx context.Context) error {
+	return p.db.PingContext(ctx)
+}
+
+// NewPingCheck creates a Check that is healthy as long as the given Pinger can be pinged within the timeout.
+func NewPingCheck(name string, pinger Pinger, options ...PingOption) (Check, error) {
+	if pinger == nil {
+		return nil, fmt.Errorf("Can't create a Check whose Pinger is nil")
+	}
+	return newPingCheck(name, pinger, nil, options...)
+}
+
+// NewSimplePingCheck creates a Check that is healthy as long as the given SimplePinger can be pinged within the timeout.
+// Since the SimplePinger is not aware of a context, a hanging ping is abandoned after the timeout.
+func NewSimplePingCheck(name string, pinger SimplePinger, options ...PingOption) (Check, error) {
+	if pinger == nil {
+		return nil, fmt.Errorf("Can't create a Check whose SimplePinger is nil")
+	}
+	return newPingCheck(name, simplePingerAdapter{pinger: pinger}, nil, options...)
+}
+
+type simplePingerAdapter struct {
+	pinger SimplePinger
+}
+
+func (a simplePingerAdapter) Ping(ctx context.Context) error {
+	errChan := make(chan error, 1)
+	go func() {
+		errChan <- a.pinger.Ping()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func newPingCheck(name string, pinger Pinger, db *sql.DB, options ...PingOption) (Check, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Can't create a Check with an empty name")
+	}
+
+	check := &pingCheck{
+		name:    name,
+		pinger:  pinger,
+		db:      db,
+		timeout: defaultPingTimeout,
+	}
+
+	// apply the options
+	for _, opt := range options {
+		if err := opt(check); err != nil {
+			return nil, fmt.Errorf("Can't create Check '%s': %w", name, err)
+		}
+	}
+	return check, nil
+}
+
+type pingCheck struct {
+	name    string
+	pinger  Pinger
+	timeout time.Duration
+	// optional, called after a successful ping
+	validate func(ctx context.Context) error
+
+	// only set for checks of a *sql.DB
+	db        *sql.DB
+	poolStats bool
+}
+
+func (c *pingCheck) IsHealthy() error {
+	return c.ping(context.Background())
+}
+
+// evaluateNested pings within the context of the evaluation (the check has no children),
+// hence the ping is aborted as soon as the Monitor abandons the evaluation
+func (c *pingCheck) evaluateNested(ctx context.Context) ([]ChildResult, error) {
+	return nil, c.ping(ctx)
+}
+
+// ping pings and validates the connection within the timeout of the check
+func (c *pingCheck) ping(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if err := c.pinger.Ping(ctx); err != nil {
+		return fmt.Errorf("Ping failed: %w", err)
+	}
+
+	if c.validate == nil {
+		return nil
+	}
+	return c.validate(ctx)
+}
+
+func (c *pingCheck) String() string {
+	return c.name
+}
+
+// Details reports the statistics of the connection pool in case they were requested via WithPoolStats
+func (c *pingCheck) Details() map[string]interface{} {
+	if !c.poolStats {
+		return nil
+	}
+
+	stats := c.db.Stats()
+	return map[string]interface{}{
+		"max_open_connections": stats.MaxOpenConnections,
+		"open_connections":     stats.OpenConnections,
+		"in_use":               stats.InUse,
+		"idle":                 stats.Idle,
+		"wait_count":           stats.WaitCount,
+		"wait_duration_ms":     durationToMS(stats.WaitDuration),
+	}
+}
@@ -0,0 +1,260 @@
+package health
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDriver is a database driver whose connections fail depending on the data source name
+type fakeDriver struct{}
+
+type fakeConn struct {
+	dsn string
+}
+
+type fakeRows struct {
+	// the error returned while the rows are read (io.EOF means there are no more rows)
+	err error
+}
+
+func init() {
+	sql.Register("health-fake", fakeDriver{})
+}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	return &fakeConn{dsn: dsn}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("not supported")
+}
+
+func (c *fakeConn) Ping(ctx context.Context) error {
+	if c.dsn == "ping-fails" {
+		return fmt.Errorf("connection refused")
+	}
+	return nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if c.dsn == "query-fails" {
+		return nil, fmt.Errorf("relation does not exist")
+	}
+	if c.dsn == "read-fails" {
+		return fakeRows{err: fmt.Errorf("canceling statement due to statement timeout")}, nil
+	}
+	return fakeRows{err: io.EOF}, nil
+}
+
+func (fakeRows) Columns() []string {
+	return nil
+}
+
+func (fakeRows) Close() error {
+	return nil
+}
+
+func (r fakeRows) Next(dest []driver.Value) error {
+	return r.err
+}
+
+func openFakeDB(t *testing.T, dsn string) *sql.DB {
+	db, err := sql.Open("health-fake", dsn)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db
+}
+
+func Test_SQLCheck(t *testing.T) {
+
+	tests := []struct {
+		dsn       string
+		options   []PingOption
+		isHealthy bool
+	}{
+		{dsn: "ok", isHealthy: true},
+		{dsn: "ping-fails", isHealthy: false},
+		{dsn: "query-fails", isHealthy: true},
+		{dsn: "query-fails", options: []PingOption{WithValidationQuery("SELECT 1")}, isHealthy: false},
+		{dsn: "ok", options: []PingOption{WithValidationQuery("SELECT 1")}, isHealthy: true},
+		{dsn: "read-fails", options: []PingOption{WithValidationQuery("SELECT 1")}, isHealthy: false},
+	}
+
+	for _, test := range tests {
+		// GIVEN
+		check, err := NewSQLCheck("db", openFakeDB(t, test.dsn), test.options...)
+		require.NoError(t, err)
+		require.NotNil(t, check)
+
+		// WHEN
+		err = check.IsHealthy()
+
+		// THEN
+		assert.Equal(t, test.isHealthy, err == nil, "dsn=%s: %v", test.dsn, err)
+		assert.Equal(t, "db", check.String())
+	}
+}
+
+func Test_SQLCheckShouldReportPoolStats(t *testing.T) {
+
+	// GIVEN
+	db := openFakeDB(t, "ok")
+	db.SetMaxOpenConns(5)
+	check, err := NewSQLCheck("db", db, WithPoolStats())
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	err = monitor.Register(check)
+	require.NoError(t, err)
+
+	// WHEN
+	checkResult := monitor.evaluateChecks(time.Now())
+
+	// THEN
+	require.NoError(t, checkResult.checkResults["db"].err)
+	details := checkResult.checkResults["db"].details
+	require.NotNil(t, details)
+	assert.Equal(t, 5, details["max_open_connections"])
+	assert.Equal(t, 1, details["open_connections"])
+	assert.Equal(t, 1, details["idle"])
+	assert.Equal(t, 0, details["in_use"])
+	assert.Equal(t, int64(0), details["wait_count"])
+}
+
+type pingerFunc func(ctx context.Context) error
+
+func (p pingerFunc) Ping(ctx context.Context) error {
+	return p(ctx)
+}
+
+type simplePingerFunc func() error
+
+func (p simplePingerFunc) Ping() error {
+	return p()
+}
+
+func Test_PingCheck(t *testing.T) {
+
+	// GIVEN
+	errPing := fmt.Errorf("broker unavailable")
+	healthy, err := NewPingCheck("broker", pingerFunc(func(ctx context.Context) error {
+		return nil
+	}))
+	require.NoError(t, err)
+	unhealthy, err := NewPingCheck("broker", pingerFunc(func(ctx context.Context) error {
+		return errPing
+	}))
+	require.NoError(t, err)
+	hanging, err := NewPingCheck("broker", pingerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}), WithPingTimeout(time.Millisecond*10))
+	require.NoError(t, err)
+	invalid, err := NewPingCheck("broker", pingerFunc(func(ctx context.Context) error {
+		return nil
+	}), WithValidation(func(ctx context.Context) error {
+		return fmt.Errorf("no leader elected")
+	}))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.ErrorIs(t, unhealthy.IsHealthy(), errPing)
+	assert.ErrorIs(t, hanging.IsHealthy(), context.DeadlineExceeded)
+	assert.EqualError(t, invalid.IsHealthy(), "no leader elected")
+}
+
+func Test_PingCheckShouldBeAbortedWithTheEvaluation(t *testing.T) {
+
+	// GIVEN
+	aborted := make(chan error, 1)
+	hanging, err := NewPingCheck("broker", pingerFunc(func(ctx context.Context) error {
+		<-ctx.Done()
+		aborted <- ctx.Err()
+		return ctx.Err()
+	}), WithPingTimeout(time.Hour))
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(hanging, WithTimeout(time.Millisecond*10)))
+
+	// WHEN
+	checkResult := monitor.evaluateChecks(time.Now())
+
+	// THEN
+	// the ping is aborted by the timeout of the Monitor instead of hanging until its own timeout
+	assert.EqualError(t, checkResult.checkResults["broker"].err, "timeout after 10ms")
+	select {
+	case err := <-aborted:
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		assert.Fail(t, "The ping was not aborted")
+	}
+}
+
+func Test_SimplePingCheck(t *testing.T) {
+
+	// GIVEN
+	release := make(chan struct{})
+	defer close(release)
+	healthy, err := NewSimplePingCheck("cache", simplePingerFunc(func() error {
+		return nil
+	}))
+	require.NoError(t, err)
+	hanging, err := NewSimplePingCheck("cache", simplePingerFunc(func() error {
+		<-release
+		return nil
+	}), WithPingTimeout(time.Millisecond*10))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, healthy.IsHealthy())
+	assert.ErrorIs(t, hanging.IsHealthy(), context.DeadlineExceeded)
+}
+
+func Test_NewPingCheckShouldFail(t *testing.T) {
+
+	pinger := pingerFunc(func(ctx context.Context) error {
+		return nil
+	})
+
+	tests := []struct {
+		name   string
+		create func() (Check, error)
+	}{
+		{name: "nil db", create: func() (Check, error) { return NewSQLCheck("db", nil) }},
+		{name: "nil pinger", create: func() (Check, error) { return NewPingCheck("broker", nil) }},
+		{name: "nil simple pinger", create: func() (Check, error) { return NewSimplePingCheck("cache", nil) }},
+		{name: "empty name", create: func() (Check, error) { return NewPingCheck(" ", pinger) }},
+		{name: "invalid timeout", create: func() (Check, error) { return NewPingCheck("broker", pinger, WithPingTimeout(0)) }},
+		{name: "query without db", create: func() (Check, error) { return NewPingCheck("broker", pinger, WithValidationQuery("SELECT 1")) }},
+		{name: "pool stats without db", create: func() (Check, error) { return NewPingCheck("broker", pinger, WithPoolStats()) }},
+	}
+
+	for _, test := range tests {
+		// WHEN
+		check, err := test.create()
+
+		// THEN
+		assert.Error(t, err, test.name)
+		assert.Nil(t, check, test.name)
+	}
+}
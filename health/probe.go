@@ -0,0 +1,41 @@
+package health
+
+import "strings"
+
+// Probe represents a group of checks that is evaluated by one dedicated probe endpoint.
+// Probes can be combined (e.g. Liveness|Readiness) to assign a check to multiple groups.
+type Probe uint8
+
+const (
+	// Liveness is the group of checks that indicates whether the service is still alive.
+	// A failing liveness probe usually results in a restart of the service.
+	Liveness Probe = 1 << iota
+	// Readiness is the group of checks that indicates whether the service is able to handle requests.
+	// A failing readiness probe usually results in the service being removed from the load balancer.
+	Readiness
+	// Startup is the group of checks that indicates whether the service has been started completely.
+	// The startup probe stays in the state "starting" until each of its checks has passed at least once.
+	Startup
+)
+
+// defaultProbes are the probes a check is assigned to if no probe was specified explicitly
+const defaultProbes = Liveness | Readiness
+
+// Has returns true in case the given probe is part of p
+func (p Probe) Has(probe Probe) bool {
+	return p&probe != 0
+}
+
+func (p Probe) String() string {
+	var names []string
+	if p.Has(Liveness) {
+		names = append(names, "liveness")
+	}
+	if p.Has(Readiness) {
+		names = append(names, "readiness")
+	}
+	if p.Has(Startup) {
+		names = append(names, "startup")
+	}
+	return strings.Join(names, "|")
+}
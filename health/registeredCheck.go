@@ -0,0 +1,390 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCheckTimeout is the time a check may take at most if no timeout was specified explicitly
+const defaultCheckTimeout = time.Second * 10
+
+// registeredCheck is a Check together with the settings it was registered with
+type registeredCheck struct {
+	// the name is obtained once at registration, since the String() of a Check may change over time
+	name  string
+	check ContextCheck
+	// nil in case the check does not provide details
+	detailsProvider DetailsProvider
+	// nil in case the check does not consist of other checks
+	nested nestedCheck
+	// nil in case the check does not report a structured Result
+	resultCheck ResultCheck
+	probes      Probe
+	severity    Severity
+	timeout     time.Duration
+	// the interval the check is evaluated in (0 means the interval of the Monitor is used)
+	interval time.Duration
+	// the result of the check is regarded as unhealthy in case it is older than staleness (0 means never)
+	staleness time.Duration
+	// the number of consecutive failures needed to regard a healthy check as unhealthy
+	failureThreshold uint
+	// the number of consecutive successes needed to regard an unhealthy check as healthy again
+	successThreshold uint
+	// the time the evaluation should take at most (0 means no budget) and whether exceeding it degrades the check
+	latencyBudget   time.Duration
+	degradeWhenSlow bool
+	// nil in case the interval should not be stretched while the check is failing
+	backoff *backoff
+
+	// true as long as an evaluation of the check is in progress (even if it was abandoned because of its timeout)
+	inProgress atomic.Bool
+	// closed as soon as the evaluation scheduled by the monitor is done, nil in case none is scheduled (guarded by the mutex of the monitor)
+	running chan struct{}
+
+	// the latest (cached) result of the check
+	lastErr         error
+	lastEvaluatedAt time.Time
+	lastDuration    time.Duration
+	lastDetails     map[string]interface{}
+	lastChildren    []ChildResult
+	lastMessage     string
+	// the error of the latest evaluation in case it was degraded
+	lastDegradedErr error
+
+	// the delay until the next evaluation while the check is failing (0 means the interval is used)
+	backoffDelay time.Duration
+
+	// the latest results and state transitions (nil means no history is kept)
+	history *checkHistory
+
+	// the state of the check after applying the thresholds
+	failing              bool
+	failingErr           error
+	consecutiveFailures  uint
+	consecutiveSuccesses uint
+
+	// true as soon as the check has been healthy at least once
+	passedOnce bool
+}
+
+func newRegisteredCheck(check ContextCheck, options ...CheckOption) (*registeredCheck, error) {
+	if check == nil {
+		return nil, fmt.Errorf("Unable to register a check that is nil")
+	}
+
+	name := check.String()
+	if len(strings.TrimSpace(name)) == 0 {
+		return nil, fmt.Errorf("Unable to register a check without a name")
+	}
+
+	rc := &registeredCheck{
+		name:     name,
+		check:    check,
+		probes:   defaultProbes,
+		severity: Critical,
+		timeout:  defaultCheckTimeout,
+
+		failureThreshold: 1,
+		successThreshold: 1,
+	}
+	rc.detailsProvider, _ = underlyingCheck(check).(DetailsProvider)
+	rc.nested, _ = underlyingCheck(check).(nestedCheck)
+	rc.resultCheck, _ = underlyingCheck(check).(ResultCheck)
+
+	// apply the options
+	for _, opt := range options {
+		opt(rc)
+	}
+
+	if rc.probes == 0 {
+		return nil, fmt.Errorf("Unable to register check '%s' without a probe", name)
+	}
+	if rc.timeout <= 0 {
+		return nil, fmt.Errorf("Unable to register check '%s' with a timeout of %s", name, rc.timeout)
+	}
+	if rc.interval < 0 {
+		return nil, fmt.Errorf("Unable to register check '%s' with an interval of %s", name, rc.interval)
+	}
+	if rc.staleness < 0 || (rc.staleness > 0 && rc.staleness < rc.interval) {
+		return nil, fmt.Errorf("Unable to register check '%s' with a staleness of %s, it has to be at least the interval (%s)", name, rc.staleness, rc.interval)
+	}
+	if rc.failureThreshold == 0 || rc.successThreshold == 0 {
+		return nil, fmt.Errorf("Unable to register check '%s' with a threshold of 0", name)
+	}
+	if rc.latencyBudget < 0 {
+		return nil, fmt.Errorf("Unable to register check '%s' with a latency budget of %s", name, rc.latencyBudget)
+	}
+	if rc.backoff != nil {
+		if err := rc.backoff.validate(); err != nil {
+			return nil, fmt.Errorf("Unable to register check '%s' with an invalid backoff: %w", name, err)
+		}
+		// otherwise a failing check would be reported as stale while it is backing off
+		if rc.staleness > 0 && rc.staleness < rc.backoff.maxDelay() {
+			return nil, fmt.Errorf("Unable to register check '%s' with a staleness of %s, it has to be at least the max delay of the backoff (%s)", name, rc.staleness, rc.backoff.maxDelay())
+		}
+	}
+	return rc, nil
+}
+
+// record records the result of an evaluation of the check at the given point in time.
+// The check is regarded as unhealthy after failureThreshold consecutive failures and
+// as healthy again after successThreshold consecutive successes.
+// The first result is taken over as is, since there is no previous state that could be damped.
+// In case the status of the check has changed the according Transition is returned.
+func (c *registeredCheck) record(eval evaluation, at time.Time) (Transition, bool) {
+	isFirstResult := c.lastEvaluatedAt.IsZero()
+	previousStatus := c.status()
+
+	err := eval.err
+	c.lastErr = err
+	c.lastEvaluatedAt = at
+	c.lastDuration = eval.duration
+	c.lastDetails = eval.details
+	c.lastChildren = eval.children
+	c.lastMessage = eval.message
+	c.lastDegradedErr = eval.degradedErr
+
+	if err != nil {
+		c.consecutiveFailures++
+		c.consecutiveSuccesses = 0
+		if isFirstResult || c.failing || c.consecutiveFailures >= c.failureThreshold {
+			c.failing = true
+			c.failingErr = err
+		}
+	} else {
+		c.consecutiveSuccesses++
+		c.consecutiveFailures = 0
+		if isFirstResult || !c.failing || c.consecutiveSuccesses >= c.successThreshold {
+			c.failing = false
+			c.failingErr = nil
+			c.passedOnce = true
+		}
+	}
+
+	// the delay is determined once per failure, hence the schedule does not change randomly
+	c.backoffDelay = 0
+	if err != nil && c.backoff != nil {
+		c.backoffDelay = c.backoff.delay(c.consecutiveFailures)
+	}
+
+	if isFirstResult {
+		previousStatus = ""
+	}
+	transition := Transition{At: at, From: previousStatus, To: c.status(), Err: eval.errOrDegraded(), Duration: eval.duration}
+	hasChanged := transition.From != transition.To
+
+	if c.history != nil {
+		status := StatusHealthy
+		if err != nil {
+			status = StatusUnhealthy
+		} else if eval.degradedErr != nil {
+			status = StatusDegraded
+		}
+		c.history.results.add(HistoryEntry{
+			At:       at,
+			Status:   status,
+			Err:      eval.errOrDegraded(),
+			Duration: eval.duration,
+			Message:  eval.message,
+			Details:  eval.details,
+		})
+
+		if hasChanged {
+			c.history.transitions.add(transition)
+		}
+	}
+	return transition, hasChanged
+}
+
+// status returns the status of the check after applying the thresholds
+func (c *registeredCheck) status() Status {
+	if c.failing {
+		return StatusUnhealthy
+	}
+	if c.isDegraded() {
+		return StatusDegraded
+	}
+	return StatusHealthy
+}
+
+// isDegraded returns true in case the latest evaluation succeeded, but was degraded
+// (i.e. the check reported a degraded result or it exceeded its latency budget)
+func (c *registeredCheck) isDegraded() bool {
+	return c.lastDegradedErr != nil || (c.isSlow() && c.degradeWhenSlow)
+}
+
+// isSlow returns true in case the latest evaluation of the check exceeded its latency budget
+func (c *registeredCheck) isSlow() bool {
+	return c.latencyBudget > 0 && c.lastDuration > c.latencyBudget
+}
+
+// historySnapshot returns a copy of the history of the check
+func (c *registeredCheck) historySnapshot() CheckHistory {
+	history := CheckHistory{Name: c.name}
+	if c.history == nil {
+		return history
+	}
+
+	history.Results = c.history.results.list()
+	history.Transitions = c.history.transitions.list()
+	return history
+}
+
+// err returns the error of the check after applying the thresholds, nil in case the check is regarded as healthy.
+func (c *registeredCheck) err() error {
+	if !c.failing {
+		return nil
+	}
+	return c.failingErr
+}
+
+// nextEvaluationAt returns the point in time the check has to be evaluated next.
+// The defaultInterval is used in case the check has no interval on its own.
+func (c *registeredCheck) nextEvaluationAt(defaultInterval time.Duration) time.Time {
+	if c.lastEvaluatedAt.IsZero() {
+		return c.lastEvaluatedAt
+	}
+
+	interval := c.interval
+	if interval == 0 {
+		interval = defaultInterval
+	}
+	if c.backoffDelay > interval {
+		interval = c.backoffDelay
+	}
+	return c.lastEvaluatedAt.Add(interval)
+}
+
+// isStale returns true in case the latest result of the check is too old at the given point in time
+func (c *registeredCheck) isStale(at time.Time) bool {
+	return c.staleness > 0 && at.Sub(c.lastEvaluatedAt) > c.staleness
+}
+
+// evaluation is the outcome of one evaluation of a check
+type evaluation struct {
+	err      error
+	duration time.Duration
+	details  map[string]interface{}
+	children []ChildResult
+	message  string
+	// the error (marked as degraded) of a check that succeeded, but was degraded (err is nil in this case)
+	degradedErr error
+}
+
+// errOrDegraded returns the error of the evaluation, regardless whether it failed or was degraded
+func (e evaluation) errOrDegraded() error {
+	if e.err != nil {
+		return e.err
+	}
+	return e.degradedErr
+}
+
+// mergeDetails merges the given details, in case of duplicate keys the latter details win
+func mergeDetails(details ...map[string]interface{}) map[string]interface{} {
+	var merged map[string]interface{}
+	for _, d := range details {
+		for key, value := range d {
+			if merged == nil {
+				merged = make(map[string]interface{})
+			}
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// encodableDetails returns the given details in case they can be encoded as JSON.
+// Otherwise a copy is returned in which each detail that can't be encoded (e.g. NaN or a channel) is replaced by the reason.
+// This way a single bad detail does not break the endpoints or the status file.
+func encodableDetails(details map[string]interface{}) map[string]interface{} {
+	if _, err := json.Marshal(details); err == nil {
+		return details
+	}
+
+	encodable := make(map[string]interface{}, len(details))
+	for key, value := range details {
+		if _, err := json.Marshal(value); err != nil {
+			encodable[key] = fmt.Sprintf("Unable to encode the detail: %s", err)
+			continue
+		}
+		encodable[key] = value
+	}
+	return encodable
+}
+
+// evaluate evaluates the check and returns its error, details and how long the evaluation took.
+// In case the check does not return within its timeout it is abandoned and reported as unhealthy.
+// The check occupies one of the given slots while it is evaluated (nil means no limit).
+func (c *registeredCheck) evaluate(ctx context.Context, clock Clock, slots chan struct{}) evaluation {
+	// the timeout starts right away, hence the time spent waiting for a free slot counts as well
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if slots != nil {
+		select {
+		case slots <- struct{}{}:
+			defer func() { <-slots }()
+		case <-ctx.Done():
+			return evaluation{err: fmt.Errorf("timeout after %s (no free slot to evaluate the check)", c.timeout)}
+		}
+	}
+
+	start := clock.Now()
+	eval := c.evaluateWithTimeout(ctx)
+	eval.duration = clock.Now().Sub(start)
+	return eval
+}
+
+// evaluateWithTimeout evaluates the check until the given context (carrying the timeout) is done
+func (c *registeredCheck) evaluateWithTimeout(ctx context.Context) evaluation {
+
+	// don't pile up evaluations of a check that still hangs in a previous evaluation
+	if !c.inProgress.CompareAndSwap(false, true) {
+		return evaluation{err: fmt.Errorf("timeout after %s (previous evaluation still in progress)", c.timeout)}
+	}
+
+	evalChan := make(chan evaluation, 1)
+	go func() {
+		defer c.inProgress.Store(false)
+		evalChan <- c.evaluateRecovered(ctx)
+	}()
+
+	select {
+	case eval := <-evalChan:
+		return eval
+	case <-ctx.Done():
+		return evaluation{err: fmt.Errorf("timeout after %s", c.timeout)}
+	}
+}
+
+// evaluateRecovered evaluates the check and obtains its details.
+// A panic of the check is recovered and results in a failed evaluation.
+func (c *registeredCheck) evaluateRecovered(ctx context.Context) (eval evaluation) {
+	defer recoverPanic(&eval.err)
+
+	switch {
+	case c.resultCheck != nil:
+		eval = resultToEvaluation(c.resultCheck.Evaluate(ctx))
+	case c.nested != nil:
+		eval.children, eval.err = c.nested.evaluateNested(ctx)
+	default:
+		eval.err = c.check.IsHealthy(ctx)
+	}
+
+	// a check that returned an error marked as degraded did not fail
+	if IsDegraded(eval.err) {
+		eval.degradedErr = eval.err
+		eval.err = nil
+	}
+
+	// the details are obtained within the same go-routine, hence they are never obtained concurrently to the evaluation
+	if c.detailsProvider != nil {
+		eval.details = mergeDetails(c.detailsProvider.Details(), eval.details)
+	}
+	eval.details = encodableDetails(eval.details)
+	return eval
+}
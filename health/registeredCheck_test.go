@@ -0,0 +1,70 @@
+package health
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_RecordShouldApplyThresholds(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("flapping", func() error {
+		return nil
+	})
+	require.NoError(t, err)
+	rc, err := newRegisteredCheck(AdaptCheck(check), WithThresholds(3, 2))
+	require.NoError(t, err)
+	errConnection := fmt.Errorf("no connection")
+	at := time.Now()
+
+	tests := []struct {
+		name                         string
+		result                       error
+		expectedErr                  error
+		expectedConsecutiveFailures  uint
+		expectedConsecutiveSuccesses uint
+	}{
+		{name: "first result is taken over", result: nil, expectedErr: nil, expectedConsecutiveSuccesses: 1},
+		{name: "1st failure", result: errConnection, expectedErr: nil, expectedConsecutiveFailures: 1},
+		{name: "2nd failure", result: errConnection, expectedErr: nil, expectedConsecutiveFailures: 2},
+		{name: "success resets failures", result: nil, expectedErr: nil, expectedConsecutiveSuccesses: 1},
+		{name: "1st failure again", result: errConnection, expectedErr: nil, expectedConsecutiveFailures: 1},
+		{name: "2nd failure again", result: errConnection, expectedErr: nil, expectedConsecutiveFailures: 2},
+		{name: "3rd failure turns unhealthy", result: errConnection, expectedErr: errConnection, expectedConsecutiveFailures: 3},
+		{name: "1st success", result: nil, expectedErr: errConnection, expectedConsecutiveSuccesses: 1},
+		{name: "2nd success turns healthy", result: nil, expectedErr: nil, expectedConsecutiveSuccesses: 2},
+	}
+
+	for _, test := range tests {
+		// WHEN
+		at = at.Add(time.Second)
+		rc.record(evaluation{err: test.result}, at)
+
+		// THEN
+		assert.Equal(t, test.expectedErr, rc.err(), test.name)
+		assert.Equal(t, test.expectedConsecutiveFailures, rc.consecutiveFailures, test.name)
+		assert.Equal(t, test.expectedConsecutiveSuccesses, rc.consecutiveSuccesses, test.name)
+		assert.Equal(t, test.result, rc.lastErr, test.name)
+		assert.Equal(t, at, rc.lastEvaluatedAt, test.name)
+	}
+}
+
+func Test_NewRegisteredCheckShouldFailOnInvalidThresholds(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("check1", func() error {
+		return nil
+	})
+	require.NoError(t, err)
+
+	// WHEN
+	rc, err := newRegisteredCheck(AdaptCheck(check), WithThresholds(0, 1))
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, rc)
+}
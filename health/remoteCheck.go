@@ -0,0 +1,247 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRemoteTimeout is the time a request to a remote health endpoint may take at most if no timeout was specified explicitly
+const defaultRemoteTimeout = time.Second * 5
+
+// maxRemoteBodySize is the number of bytes of the response of a remote health endpoint that are read at most
+const maxRemoteBodySize = 1024 * 1024
+
+// RemoteOption represents an option for the checks created by NewRemoteHealthCheck
+type RemoteOption func(c *remoteCheck) error
+
+// WithRemoteTimeout specifies the time the request to the remote health endpoint may take at most (default 5s)
+func WithRemoteTimeout(timeout time.Duration) RemoteOption {
+	return func(c *remoteCheck) error {
+		if timeout <= 0 {
+			return fmt.Errorf("Invalid timeout %s", timeout)
+		}
+		c.timeout = timeout
+		return nil
+	}
+}
+
+// WithRemoteClient specifies the http.Client that is used to request the remote health endpoint (default http.DefaultClient)
+func WithRemoteClient(client *http.Client) RemoteOption {
+	return func(c *remoteCheck) error {
+		if client == nil {
+			return fmt.Errorf("The client is nil")
+		}
+		c.client = client
+		return nil
+	}
+}
+
+// WithRemoteToken specifies the token that is passed as bearer token to the remote health endpoint.
+// This is needed in case the remote service restricts the results of its checks (see WithRestrictedDetails).
+func WithRemoteToken(token string) RemoteOption {
+	return func(c *remoteCheck) error {
+		if len(token) == 0 {
+			return fmt.Errorf("The token is empty")
+		}
+		c.token = token
+		return nil
+	}
+}
+
+// WithCaching specifies the time the result of the remote health endpoint is cached.
+// This way several Monitors (or composite checks) can share the check without putting load on the remote service.
+func WithCaching(ttl time.Duration) RemoteOption {
+	return func(c *remoteCheck) error {
+		if ttl < 0 {
+			return fmt.Errorf("Invalid cache duration %s", ttl)
+		}
+		c.cacheTTL = ttl
+		return nil
+	}
+}
+
+// WithDegradedAsUnhealthy regards the remote service as unhealthy already in case its status is StatusDegraded.
+// Per default only StatusUnhealthy, StatusStarting, StatusMaintenance (or an unreachable endpoint) result in a failing check.
+func WithDegradedAsUnhealthy() RemoteOption {
+	return func(c *remoteCheck) error {
+		c.degradedAsUnhealthy = true
+		return nil
+	}
+}
+
+// NewRemoteHealthCheck creates a Check that polls the health endpoint (e.g. http://orders:8080/health) of another service based on go-base.
+// The checks of the remote service are reported as nested results of the check.
+// The criticality of the remote service is specified on registration (e.g. WithSeverity(NonCritical)).
+func NewRemoteHealthCheck(name string, url string, options ...RemoteOption) (Check, error) {
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Can't create a Check with an empty name")
+	}
+
+	if len(strings.TrimSpace(url)) == 0 {
+		return nil, fmt.Errorf("Can't create Check '%s' without a url", name)
+	}
+
+	check := &remoteCheck{
+		name:    name,
+		url:     url,
+		client:  http.DefaultClient,
+		timeout: defaultRemoteTimeout,
+		now:     time.Now,
+	}
+
+	// apply the options
+	for _, opt := range options {
+		if err := opt(check); err != nil {
+			return nil, fmt.Errorf("Can't create Check '%s': %w", name, err)
+		}
+	}
+	return check, nil
+}
+
+type remoteCheck struct {
+	name                string
+	url                 string
+	client              *http.Client
+	timeout             time.Duration
+	cacheTTL            time.Duration
+	degradedAsUnhealthy bool
+	token               string
+	now                 func() time.Time
+
+	// the cached result of the latest request
+	cachedAt       time.Time
+	cachedChildren []ChildResult
+	cachedErr      error
+	mux            sync.Mutex
+}
+
+func (c *remoteCheck) IsHealthy() error {
+	_, err := c.evaluateNested(context.Background())
+	return err
+}
+
+func (c *remoteCheck) String() string {
+	return c.name
+}
+
+// evaluateNested requests the remote health endpoint, unless there is a cached result
+func (c *remoteCheck) evaluateNested(ctx context.Context) ([]ChildResult, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	now := c.now()
+	if c.cacheTTL > 0 && !c.cachedAt.IsZero() && now.Sub(c.cachedAt) < c.cacheTTL {
+		return c.cachedChildren, c.cachedErr
+	}
+
+	children, err := c.request(ctx)
+	c.cachedAt = now
+	c.cachedChildren = children
+	c.cachedErr = err
+	return children, err
+}
+
+func (c *remoteCheck) request(ctx context.Context) ([]ChildResult, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to create request for '%s': %w", c.url, err)
+	}
+	if len(c.token) > 0 {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Unable to request '%s': %w", c.url, err)
+	}
+	defer resp.Body.Close()
+
+	// the health endpoints answer with 503 in case the service is unhealthy, but the body still contains the result
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRemoteBodySize))
+	if err != nil {
+		return nil, fmt.Errorf("Unable to read response of '%s': %w", c.url, err)
+	}
+
+	var remote response
+	if err := json.Unmarshal(body, &remote); err != nil {
+		return nil, fmt.Errorf("Unable to parse response of '%s' (status code %d): %w", c.url, resp.StatusCode, err)
+	}
+
+	children := remoteChecksToChildResults(remote.Checks)
+	status := Status(remote.Status)
+	switch {
+	case status == StatusHealthy:
+		return children, nil
+	case status == StatusDegraded && !c.degradedAsUnhealthy:
+		return children, nil
+	case status == StatusDegraded || status == StatusUnhealthy:
+		return children, fmt.Errorf("Remote service is %s (failing checks: %s)", status, strings.Join(failingChecks(children), ", "))
+	case status == StatusStarting:
+		return children, fmt.Errorf("Remote service is still starting (pending checks: %s)", strings.Join(failingChecks(children), ", "))
+	case status == StatusMaintenance:
+		reason := ""
+		if remote.Maintenance != nil {
+			reason = remote.Maintenance.Reason
+		}
+		return children, fmt.Errorf("Remote service is in maintenance (reason: %s)", reason)
+	}
+	return children, fmt.Errorf("Remote service reported the unknown status '%s' (status code %d)", remote.Status, resp.StatusCode)
+}
+
+func remoteChecksToChildResults(checks []check) []ChildResult {
+	results := make([]ChildResult, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, ChildResult{
+			Name:     check.Name,
+			Status:   Status(check.Status),
+			Err:      remoteError(check.Status, check.Error),
+			Children: remoteChildChecksToChildResults(check.Checks),
+		})
+	}
+	return results
+}
+
+func remoteChildChecksToChildResults(checks []childCheck) []ChildResult {
+	var results []ChildResult
+	for _, check := range checks {
+		results = append(results, ChildResult{
+			Name:     check.Name,
+			Status:   Status(check.Status),
+			Err:      remoteError(check.Status, check.Error),
+			Children: remoteChildChecksToChildResults(check.Checks),
+		})
+	}
+	return results
+}
+
+// remoteError returns the error of a remote check, nil in case the check is healthy
+func remoteError(status string, msg string) error {
+	if Status(status) == StatusHealthy {
+		return nil
+	}
+	if len(msg) == 0 {
+		msg = status
+	}
+	return errors.New(msg)
+}
+
+func failingChecks(results []ChildResult) []string {
+	var names []string
+	for _, result := range results {
+		if result.Err != nil {
+			names = append(names, result.Name)
+		}
+	}
+	return names
+}
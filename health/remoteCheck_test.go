@@ -0,0 +1,196 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRemoteService(t *testing.T, statusCode int, body string, numRequests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if numRequests != nil {
+			atomic.AddInt32(numRequests, 1)
+		}
+		w.WriteHeader(statusCode)
+		fmt.Fprint(w, body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func Test_RemoteHealthCheckShouldBeHealthy(t *testing.T) {
+
+	// GIVEN
+	server := newRemoteService(t, http.StatusOK, `{"status":"healthy","checks":[
+		{"name":"db","status":"healthy"},
+		{"name":"brokers","status":"healthy","checks":[{"name":"broker-1","status":"healthy"},{"name":"broker-2","status":"unhealthy","error":"timeout"}]}
+	]}`, nil)
+	check, err := NewRemoteHealthCheck("orders", server.URL)
+	require.NoError(t, err)
+
+	// WHEN
+	children, err := check.(nestedCheck).evaluateNested(context.Background())
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "orders", check.String())
+	assert.Equal(t, []ChildResult{
+		{Name: "db", Status: StatusHealthy},
+		{Name: "brokers", Status: StatusHealthy, Children: []ChildResult{
+			{Name: "broker-1", Status: StatusHealthy},
+			{Name: "broker-2", Status: StatusUnhealthy, Err: fmt.Errorf("timeout")},
+		}},
+	}, children)
+}
+
+func Test_RemoteHealthCheckShouldBeUnhealthy(t *testing.T) {
+
+	// GIVEN
+	server := newRemoteService(t, http.StatusServiceUnavailable, `{"status":"unhealthy","checks":[
+		{"name":"db","status":"unhealthy","error":"connection refused"},
+		{"name":"cache","status":"healthy"}
+	]}`, nil)
+	check, err := NewRemoteHealthCheck("orders", server.URL)
+	require.NoError(t, err)
+
+	// WHEN
+	err = check.IsHealthy()
+
+	// THEN
+	assert.EqualError(t, err, "Remote service is unhealthy (failing checks: db)")
+}
+
+func Test_RemoteHealthCheckShouldReportStartingAndMaintenance(t *testing.T) {
+
+	// GIVEN
+	starting := newRemoteService(t, http.StatusServiceUnavailable, `{"status":"starting","checks":[{"name":"migration","status":"unhealthy","error":"running"}]}`, nil)
+	inMaintenance := newRemoteService(t, http.StatusServiceUnavailable, `{"status":"maintenance","checks":[],"maintenance":{"reason":"db migration","since":"2020-04-27T10:30:00Z"}}`, nil)
+	startingCheck, err := NewRemoteHealthCheck("orders", starting.URL)
+	require.NoError(t, err)
+	maintenanceCheck, err := NewRemoteHealthCheck("orders", inMaintenance.URL)
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.EqualError(t, startingCheck.IsHealthy(), "Remote service is still starting (pending checks: migration)")
+	assert.EqualError(t, maintenanceCheck.IsHealthy(), "Remote service is in maintenance (reason: db migration)")
+}
+
+func Test_RemoteHealthCheckShouldRespectCriticality(t *testing.T) {
+
+	// GIVEN
+	server := newRemoteService(t, http.StatusOK, `{"status":"degraded","checks":[{"name":"cache","status":"unhealthy","error":"timeout"}]}`, nil)
+	check, err := NewRemoteHealthCheck("orders", server.URL)
+	require.NoError(t, err)
+	strictCheck, err := NewRemoteHealthCheck("orders", server.URL, WithDegradedAsUnhealthy())
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.NoError(t, check.IsHealthy())
+	assert.EqualError(t, strictCheck.IsHealthy(), "Remote service is degraded (failing checks: cache)")
+}
+
+func Test_RemoteHealthCheckShouldFailOnInvalidResponse(t *testing.T) {
+
+	// GIVEN
+	server := newRemoteService(t, http.StatusNotFound, "404 page not found", nil)
+	check, err := NewRemoteHealthCheck("orders", server.URL)
+	require.NoError(t, err)
+	unreachable, err := NewRemoteHealthCheck("orders", "http://127.0.0.1:1/health", WithRemoteTimeout(time.Second))
+	require.NoError(t, err)
+
+	// WHEN + THEN
+	assert.Error(t, check.IsHealthy())
+	assert.Error(t, unreachable.IsHealthy())
+}
+
+func Test_RemoteHealthCheckShouldLimitTheResponseSize(t *testing.T) {
+
+	// GIVEN
+	server := newRemoteService(t, http.StatusOK, `{"status":"healthy","checks":[],"padding":"`+strings.Repeat("x", maxRemoteBodySize)+`"}`, nil)
+	check, err := NewRemoteHealthCheck("orders", server.URL)
+	require.NoError(t, err)
+
+	// WHEN
+	err = check.IsHealthy()
+
+	// THEN
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Unable to parse response")
+}
+
+func Test_RemoteHealthCheckShouldCacheResult(t *testing.T) {
+
+	// GIVEN
+	var numRequests int32
+	server := newRemoteService(t, http.StatusOK, `{"status":"healthy","checks":[]}`, &numRequests)
+	check, err := NewRemoteHealthCheck("orders", server.URL, WithCaching(time.Minute))
+	require.NoError(t, err)
+	now := time.Now()
+	check.(*remoteCheck).now = func() time.Time { return now }
+
+	// WHEN
+	require.NoError(t, check.IsHealthy())
+	now = now.Add(time.Second * 59)
+	require.NoError(t, check.IsHealthy())
+
+	// THEN
+	assert.Equal(t, int32(1), atomic.LoadInt32(&numRequests))
+
+	// WHEN
+	now = now.Add(time.Second)
+	require.NoError(t, check.IsHealthy())
+
+	// THEN
+	assert.Equal(t, int32(2), atomic.LoadInt32(&numRequests))
+}
+
+func Test_NewRemoteHealthCheckShouldFail(t *testing.T) {
+
+	// WHEN + THEN
+	_, err := NewRemoteHealthCheck("", "http://orders/health")
+	assert.Error(t, err)
+	_, err = NewRemoteHealthCheck("orders", "")
+	assert.Error(t, err)
+	_, err = NewRemoteHealthCheck("orders", "http://orders/health", WithRemoteTimeout(0))
+	assert.Error(t, err)
+	_, err = NewRemoteHealthCheck("orders", "http://orders/health", WithCaching(-time.Second))
+	assert.Error(t, err)
+	_, err = NewRemoteHealthCheck("orders", "http://orders/health", WithRemoteClient(nil))
+	assert.Error(t, err)
+}
+
+func Test_RemoteHealthCheckShouldReportNestedResults(t *testing.T) {
+
+	// GIVEN
+	downstream, err := NewMonitor()
+	require.NoError(t, err)
+	db, err := NewSimpleCheck("db", func() error { return fmt.Errorf("connection refused") })
+	require.NoError(t, err)
+	require.NoError(t, downstream.RegisterCheck(db, WithSeverity(NonCritical)))
+	downstream.EvaluateNow()
+	server := httptest.NewServer(http.HandlerFunc(downstream.Health))
+	defer server.Close()
+
+	remote, err := NewRemoteHealthCheck("orders", server.URL)
+	require.NoError(t, err)
+	gateway, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, gateway.Register(remote))
+
+	// WHEN
+	gateway.EvaluateNow()
+	_, response := checkEvaluationResultToResponse(gateway.latestCheckResult.Load().(checkEvaluationResult), time.Now(), time.Second*30)
+
+	// THEN
+	require.Len(t, response.Checks, 1)
+	assert.Equal(t, "healthy", response.Checks[0].Status)
+	assert.Equal(t, []childCheck{{Name: "db", Status: "unhealthy", Error: "connection refused"}}, response.Checks[0].Checks)
+}
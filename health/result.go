@@ -0,0 +1,108 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// Result is the structured result of a ResultCheck
+type Result struct {
+	// StatusHealthy, StatusDegraded or StatusUnhealthy (an empty status is regarded as StatusHealthy)
+	Status  Status
+	Message string
+	// additional information (e.g. the replication lag), values that can't be encoded as JSON are replaced by the reason
+	Details map[string]interface{}
+}
+
+// ResultCheck is a health check that reports a structured Result instead of an error
+type ResultCheck interface {
+
+	// Evaluate is called to obtain the health state of the ResultCheck.
+	// The evaluation should be aborted as soon as the given context is done.
+	Evaluate(ctx context.Context) Result
+
+	// String ... to meet the Stringer interface
+	String() string
+}
+
+// DegradedError marks the error of a check as degraded, i.e. the check is not healthy but it does not fail.
+// A degraded check turns the overall status to StatusDegraded, regardless of its severity.
+type DegradedError struct {
+	Err error
+}
+
+func (e *DegradedError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DegradedError) Unwrap() error {
+	return e.Err
+}
+
+// Degraded marks the given error as degraded, e.g. a Check can return
+//
+//	health.Degraded(fmt.Errorf("replication lag is %s", lag))
+//
+// to report that it still works, but not as expected.
+func Degraded(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &DegradedError{Err: err}
+}
+
+// IsDegraded returns true in case the given error was marked as degraded.
+// Only the chain of wrapped errors is considered, hence a failed composite check is not degraded because one of its children is.
+func IsDegraded(err error) bool {
+	for ; err != nil; err = errors.Unwrap(err) {
+		if _, ok := err.(*DegradedError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// AdaptResultCheck turns the given ResultCheck into a ContextCheck.
+// A Monitor obtains the whole Result of the check, including its message and details.
+func AdaptResultCheck(check ResultCheck) ContextCheck {
+	if check == nil {
+		return nil
+	}
+	return resultCheckAdapter{check: check}
+}
+
+type resultCheckAdapter struct {
+	check ResultCheck
+}
+
+func (c resultCheckAdapter) IsHealthy(ctx context.Context) error {
+	return resultToEvaluation(c.check.Evaluate(ctx)).errOrDegraded()
+}
+
+func (c resultCheckAdapter) String() string {
+	return c.check.String()
+}
+
+// resultToEvaluation turns the given Result into an evaluation
+func resultToEvaluation(result Result) evaluation {
+	eval := evaluation{message: result.Message, details: result.Details}
+
+	switch result.Status {
+	case StatusHealthy, "":
+	case StatusDegraded:
+		eval.degradedErr = Degraded(errors.New(messageOr(result.Message, string(StatusDegraded))))
+	case StatusUnhealthy:
+		eval.err = errors.New(messageOr(result.Message, string(StatusUnhealthy)))
+	default:
+		eval.err = fmt.Errorf("Invalid status '%s' (%s)", result.Status, result.Message)
+	}
+	return eval
+}
+
+func messageOr(message string, fallback string) string {
+	if len(message) == 0 {
+		return fallback
+	}
+	return message
+}
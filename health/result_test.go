@@ -0,0 +1,231 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type replicationCheck struct {
+	result Result
+}
+
+func (c *replicationCheck) Evaluate(ctx context.Context) Result {
+	return c.result
+}
+
+func (c *replicationCheck) String() string {
+	return "replication"
+}
+
+func Test_Degraded(t *testing.T) {
+
+	// GIVEN
+	errLag := fmt.Errorf("replication lag is 10s")
+
+	// WHEN
+	err := Degraded(errLag)
+
+	// THEN
+	assert.EqualError(t, err, "replication lag is 10s")
+	assert.True(t, IsDegraded(err))
+	assert.True(t, IsDegraded(fmt.Errorf("replica-1: %w", err)))
+	assert.True(t, errors.Is(err, errLag))
+	assert.False(t, IsDegraded(errLag))
+	assert.NoError(t, Degraded(nil))
+}
+
+func Test_ResultToEvaluation(t *testing.T) {
+
+	// WHEN + THEN
+	eval := resultToEvaluation(Result{Message: "lag 1s", Details: map[string]interface{}{"lag_seconds": 1}})
+	assert.NoError(t, eval.err)
+	assert.NoError(t, eval.degradedErr)
+	assert.Equal(t, "lag 1s", eval.message)
+	assert.Equal(t, 1, eval.details["lag_seconds"])
+
+	eval = resultToEvaluation(Result{Status: StatusDegraded, Message: "lag 10s"})
+	assert.NoError(t, eval.err)
+	assert.EqualError(t, eval.degradedErr, "lag 10s")
+	assert.True(t, IsDegraded(eval.degradedErr))
+
+	eval = resultToEvaluation(Result{Status: StatusUnhealthy})
+	assert.EqualError(t, eval.err, "unhealthy")
+
+	eval = resultToEvaluation(Result{Status: StatusStarting, Message: "lag 1s"})
+	assert.EqualError(t, eval.err, "Invalid status 'starting' (lag 1s)")
+}
+
+func Test_EvaluateChecksShouldReportResults(t *testing.T) {
+
+	// GIVEN
+	replication := &replicationCheck{result: Result{
+		Status:  StatusDegraded,
+		Message: "replication lag is 10s",
+		Details: map[string]interface{}{"lag_seconds": 10},
+	}}
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterResultCheck(replication))
+
+	// WHEN
+	now := time.Now()
+	result := monitor.evaluateChecks(now)
+	_, response := checkEvaluationResultToResponse(result, now, time.Second*30)
+
+	// THEN
+	assert.Equal(t, StatusDegraded, result.status())
+	assert.Equal(t, uint(0), result.numErrors)
+	require.Len(t, response.Checks, 1)
+	assert.Equal(t, "degraded", response.Checks[0].Status)
+	assert.Equal(t, "replication lag is 10s", response.Checks[0].Error)
+	assert.Equal(t, "replication lag is 10s", response.Checks[0].Message)
+	assert.Equal(t, 10, response.Checks[0].Details["lag_seconds"])
+
+	history, err := monitor.CheckHistory("replication")
+	require.NoError(t, err)
+	require.Len(t, history.Results, 1)
+	assert.Equal(t, StatusDegraded, history.Results[0].Status)
+	assert.Equal(t, "replication lag is 10s", history.Results[0].Message)
+	assert.Equal(t, 10, history.Results[0].Details["lag_seconds"])
+	require.Len(t, history.Transitions, 1)
+	assert.Equal(t, StatusDegraded, history.Transitions[0].To)
+
+	// WHEN
+	replication.result = Result{Status: StatusUnhealthy, Message: "replica is down"}
+	result = monitor.evaluateChecks(now.Add(monitor.checkInterval))
+
+	// THEN
+	assert.Equal(t, StatusUnhealthy, result.status())
+	assert.EqualError(t, result.checkResults["replication"].err, "replica is down")
+}
+
+func Test_EvaluateChecksShouldRegardDegradedErrorsAsDegraded(t *testing.T) {
+
+	// GIVEN
+	check, err := NewSimpleCheck("queue", func() error { return Degraded(fmt.Errorf("queue depth is 10000")) })
+	require.NoError(t, err)
+	monitor, err := NewMonitor()
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(check))
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	recorder := httptest.NewRecorder()
+	monitor.Health(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), `"status":"degraded"`)
+	assert.Contains(t, recorder.Body.String(), `"error":"queue depth is 10000"`)
+	assert.Equal(t, StatusDegraded, monitor.Status())
+}
+
+func Test_CompositeCheckShouldBeDegradedByDegradedChild(t *testing.T) {
+
+	// GIVEN
+	degraded, err := NewSimpleCheck("replica-1", func() error { return Degraded(fmt.Errorf("slow")) })
+	require.NoError(t, err)
+	composite, err := AllOf("replicas", degraded)
+	require.NoError(t, err)
+
+	// WHEN
+	children, err := composite.(nestedCheck).evaluateNested(context.Background())
+
+	// THEN
+	// a degraded child still counts as healthy, but it degrades the composite
+	assert.True(t, IsDegraded(err))
+	assert.EqualError(t, err, "1 of 1 checks healthy (1 required): replica-1: slow")
+	require.Len(t, children, 1)
+	assert.Equal(t, StatusDegraded, children[0].Status)
+}
+
+func Test_CompositeCheckShouldNotBeDegradedByDegradedChildWithoutQuorum(t *testing.T) {
+
+	// GIVEN
+	degraded, err := NewSimpleCheck("replica-1", func() error { return Degraded(fmt.Errorf("slow")) })
+	require.NoError(t, err)
+	failing, err := NewSimpleCheck("replica-2", func() error { return fmt.Errorf("connection refused") })
+	require.NoError(t, err)
+	composite, err := AllOf("replicas", degraded, failing)
+	require.NoError(t, err)
+
+	// WHEN
+	_, err = composite.(nestedCheck).evaluateNested(context.Background())
+
+	// THEN
+	assert.False(t, IsDegraded(err))
+	assert.EqualError(t, err, "1 of 2 checks healthy (2 required): replica-1: slow; replica-2: connection refused")
+}
+
+func Test_EndpointsShouldSanitizeMessages(t *testing.T) {
+
+	// GIVEN
+	replication := &replicationCheck{result: Result{Status: StatusUnhealthy, Message: "cannot reach db.internal as admin"}}
+	monitor, err := NewMonitor(WithErrorSanitizer(func(check string, message string) string { return "redacted" }))
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterResultCheck(replication))
+	monitor.EvaluateNow()
+
+	// WHEN
+	healthRecorder := httptest.NewRecorder()
+	monitor.Health(healthRecorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+	historyRecorder := httptest.NewRecorder()
+	monitor.History(historyRecorder, httptest.NewRequest(http.MethodGet, "/health/history", nil))
+
+	// THEN
+	assert.Contains(t, healthRecorder.Body.String(), `"message":"redacted"`)
+	assert.NotContains(t, healthRecorder.Body.String(), "db.internal")
+	assert.Contains(t, historyRecorder.Body.String(), `"message":"redacted"`)
+	assert.NotContains(t, historyRecorder.Body.String(), "db.internal")
+}
+
+func Test_EndpointsShouldReportDetailsThatCantBeEncoded(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{name: "NaN", value: math.NaN()},
+		{name: "chan", value: make(chan int)},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// GIVEN
+			path := filepath.Join(t.TempDir(), "health.json")
+			replication := &replicationCheck{result: Result{Details: map[string]interface{}{"lag_seconds": 1, "bad": test.value}}}
+			monitor, err := NewMonitor(WithStatusFile(path))
+			require.NoError(t, err)
+			require.NoError(t, monitor.RegisterResultCheck(replication))
+			monitor.EvaluateNow()
+
+			// WHEN
+			recorder := httptest.NewRecorder()
+			monitor.Health(recorder, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+			// THEN
+			assert.Equal(t, http.StatusOK, recorder.Code)
+			var resp response
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+			require.Len(t, resp.Checks, 1)
+			assert.Equal(t, float64(1), resp.Checks[0].Details["lag_seconds"])
+			assert.Contains(t, resp.Checks[0].Details["bad"], "Unable to encode the detail")
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Contains(t, string(data), `"status":"healthy"`)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package health
 
 import (
+	"context"
 	"fmt"
 	"strings"
 )
@@ -42,3 +43,38 @@ func (s simpleCheck) IsHealthy() error {
 func (s simpleCheck) String() string {
 	return s.name
 }
+
+// ContextCheckFun is a function that is called in order to evaluate a ContextCheck.
+// It should return nil if the check is healthy and abort as soon as the given context is done.
+type ContextCheckFun func(ctx context.Context) error
+
+// NewSimpleContextCheck creates a ContextCheck based on a given ContextCheckFun and a name.
+func NewSimpleContextCheck(name string, check ContextCheckFun) (ContextCheck, error) {
+
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return nil, fmt.Errorf("Can't create a Check with an empty name")
+	}
+
+	if check == nil {
+		return nil, fmt.Errorf("Can't create a Check whose ContextCheckFun is nil")
+	}
+
+	return simpleContextCheck{
+		name:  name,
+		check: check,
+	}, nil
+}
+
+type simpleContextCheck struct {
+	name  string
+	check ContextCheckFun
+}
+
+func (s simpleContextCheck) IsHealthy(ctx context.Context) error {
+	return s.check(ctx)
+}
+
+func (s simpleContextCheck) String() string {
+	return s.name
+}
@@ -1,6 +1,7 @@
 package health
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
@@ -42,3 +43,36 @@ func Test_NewSimpleCheckShouldFail(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, check)
 }
+
+func Test_NewSimpleContextCheck(t *testing.T) {
+
+	// GIVEN
+	checkFun := func(ctx context.Context) error {
+		return ctx.Err()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// WHEN
+	check, err := NewSimpleContextCheck("check1", checkFun)
+
+	// THEN
+	assert.NoError(t, err)
+	assert.NotNil(t, check)
+	assert.Equal(t, context.Canceled, check.IsHealthy(ctx))
+	assert.Equal(t, "check1", check.String())
+
+	// WHEN
+	check, err = NewSimpleContextCheck("check1", nil)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+
+	// WHEN
+	check, err = NewSimpleContextCheck(" ", checkFun)
+
+	// THEN
+	assert.Error(t, err)
+	assert.Nil(t, check)
+}
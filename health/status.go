@@ -0,0 +1,35 @@
+package health
+
+// Status represents the health status of the service or of a single check
+type Status string
+
+const (
+	// StatusHealthy means that all checks are healthy
+	StatusHealthy Status = "healthy"
+	// StatusDegraded means that at least one non-critical check is unhealthy, but all critical checks are healthy.
+	// The service is still able to handle requests.
+	StatusDegraded Status = "degraded"
+	// StatusUnhealthy means that at least one critical check is unhealthy
+	StatusUnhealthy Status = "unhealthy"
+	// StatusStarting means that not all checks of the Startup probe have passed yet
+	StatusStarting Status = "starting"
+	// StatusMaintenance means that the service was put into maintenance explicitly, hence it should not receive requests
+	StatusMaintenance Status = "maintenance"
+)
+
+// Severity defines how a failing check affects the overall health status
+type Severity int
+
+const (
+	// Critical checks turn the overall health status to unhealthy in case they fail (default)
+	Critical Severity = iota
+	// NonCritical checks turn the overall health status to degraded in case they fail
+	NonCritical
+)
+
+func (s Severity) String() string {
+	if s == NonCritical {
+		return "non-critical"
+	}
+	return "critical"
+}
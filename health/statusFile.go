@@ -0,0 +1,43 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// writeStatusFile writes the given result to the status file (if configured) in the format of the health endpoint.
+// The file is replaced atomically, hence a reader never sees a partially written file.
+func (m *Monitor) writeStatusFile(result checkEvaluationResult) {
+	if len(m.statusFile) == 0 {
+		return
+	}
+
+	_, response := checkEvaluationResultToResponse(result, result.at, m.checkEvaluationTimeout)
+	if err := writeStatusFile(m.statusFile, m.sanitizeResponse(response)); err != nil {
+		m.logger.Error().Err(err).Msgf("Unable to write status file '%s'", m.statusFile)
+	}
+}
+
+func writeStatusFile(path string, response response) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("Unable to marshal the status: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,55 @@
+package health
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_MonitorShouldWriteStatusFile(t *testing.T) {
+
+	// GIVEN
+	path := filepath.Join(t.TempDir(), "health.json")
+	failing, err := NewSimpleCheck("db", func() error { return fmt.Errorf("connection refused") })
+	require.NoError(t, err)
+	monitor, err := NewMonitor(WithStatusFile(path))
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(failing))
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var status response
+	require.NoError(t, json.Unmarshal(data, &status))
+	assert.Equal(t, "unhealthy", status.Status)
+	require.Len(t, status.Checks, 1)
+	assert.Equal(t, "connection refused", status.Checks[0].Error)
+
+	// the file is replaced without leaving temporary files behind
+	entries, err := os.ReadDir(filepath.Dir(path))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func Test_MonitorShouldNotWriteStatusFileToInvalidPath(t *testing.T) {
+
+	// GIVEN
+	path := filepath.Join(t.TempDir(), "missing", "health.json")
+	monitor, err := NewMonitor(WithStatusFile(path))
+	require.NoError(t, err)
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err))
+}
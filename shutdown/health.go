@@ -6,6 +6,10 @@ import (
 
 // IsHealthy returns an error in case a shutdown is currently in progress.
 // The error is returned to indicate that the service is not healthy any more (can't handle any requests)
+// Since draining should not result in a restart of the service the handler should be registered
+// for the readiness probe only:
+//
+//	monitor.RegisterCheck(shutdownHandler, health.ForProbes(health.Readiness))
 func (h *ShutdownHandler) IsHealthy() error {
 	if h.isShutdownPending.Load() {
 		return fmt.Errorf("Shutdown in progress")
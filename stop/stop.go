@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/rs/zerolog"
+	"runtime/debug"
 	"sync"
 )
 
@@ -54,9 +55,9 @@ func (l *Registry) StopAllInOrder(logger zerolog.Logger) error {
 
 func stop(stoppableItems []Stoppable, logger zerolog.Logger) {
 	for _, stoppable := range stoppableItems {
-		serviceName := stoppable.String()
+		serviceName := nameOf(stoppable, logger)
 		logger.Debug().Msgf("Stopping %s ...", serviceName)
-		err := stoppable.Stop()
+		err := stopRecovered(stoppable, serviceName, logger)
 		if err != nil {
 			logger.Error().Err(err).Bool("no_alert", true).Msgf("Failed stopping '%s'", serviceName)
 			continue
@@ -64,3 +65,30 @@ func stop(stoppableItems []Stoppable, logger zerolog.Logger) {
 		logger.Info().Msgf("%s stopped.", serviceName)
 	}
 }
+
+// stopRecovered stops the given stoppable, a panic is recovered and results in a failed stop.
+// This way the remaining stoppables are still stopped.
+func stopRecovered(stoppable Stoppable, serviceName string, logger zerolog.Logger) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error().Str("stack", string(debug.Stack())).Bool("no_alert", true).Msgf("Panic while stopping '%s': %v", serviceName, r)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return stoppable.Stop()
+}
+
+// unknownServiceName is used as name of a stoppable whose String() panics
+const unknownServiceName = "<unknown service>"
+
+// nameOf returns the name of the given stoppable, a panic is recovered and results in a placeholder name.
+// This way a stoppable with a broken String() is still stopped.
+func nameOf(stoppable Stoppable, logger zerolog.Logger) (name string) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Error().Str("stack", string(debug.Stack())).Bool("no_alert", true).Msgf("Panic while obtaining the name of a service: %v", r)
+			name = unknownServiceName
+		}
+	}()
+	return stoppable.String()
+}
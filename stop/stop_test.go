@@ -1,6 +1,7 @@
 package stop
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/golang/mock/gomock"
 	"github.com/rs/zerolog"
@@ -96,6 +97,62 @@ func Test_listener_is_called_when_a_service_could_not_be_stopped_without_error(t
 	require.NoError(t, err)
 }
 
+func Test_remaining_items_are_stopped_if_a_stoppable_panics(t *testing.T) {
+	// GIVEN
+	stoppableList, ctrl, stoppable1, stoppable2, stoppable3 := createDefaultStopScenario2(t)
+	defer ctrl.Finish()
+	logs := bytes.Buffer{}
+
+	// IGNORE
+	stoppable3.EXPECT().String().Return("service 3").AnyTimes()
+	stoppable2.EXPECT().String().Return("service 2").AnyTimes()
+	stoppable1.EXPECT().String().Return("service 1").AnyTimes()
+
+	// EXPECT
+	gomock.InOrder(
+		stoppable3.EXPECT().Stop(),
+		stoppable2.EXPECT().Stop().Do(func() { panic("boom") }),
+		stoppable1.EXPECT().Stop(),
+	)
+
+	// WHEN
+	err := stoppableList.StopAllInOrder(zerolog.New(&logs))
+
+	// THEN
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "Panic while stopping 'service 2': boom")
+	assert.Contains(t, logs.String(), "\"stack\":\"goroutine")
+	assert.Contains(t, logs.String(), "Failed stopping 'service 2'")
+}
+
+func Test_remaining_items_are_stopped_if_the_name_of_a_stoppable_panics(t *testing.T) {
+	// GIVEN
+	stoppableList, ctrl, stoppable1, stoppable2, stoppable3 := createDefaultStopScenario2(t)
+	defer ctrl.Finish()
+	logs := bytes.Buffer{}
+
+	// IGNORE
+	stoppable3.EXPECT().String().Return("service 3").AnyTimes()
+	stoppable2.EXPECT().String().Do(func() { panic("boom") }).AnyTimes()
+	stoppable1.EXPECT().String().Return("service 1").AnyTimes()
+
+	// EXPECT
+	gomock.InOrder(
+		stoppable3.EXPECT().Stop(),
+		stoppable2.EXPECT().Stop(),
+		stoppable1.EXPECT().Stop(),
+	)
+
+	// WHEN
+	err := stoppableList.StopAllInOrder(zerolog.New(&logs))
+
+	// THEN
+	require.NoError(t, err)
+	assert.Contains(t, logs.String(), "Panic while obtaining the name of a service: boom")
+	assert.Contains(t, logs.String(), "<unknown service> stopped.")
+	assert.Contains(t, logs.String(), "service 1 stopped.")
+}
+
 func Test_returns_error_if_stop_is_in_progress_or_complete(t *testing.T) {
 	stoppableList := Registry{}
 
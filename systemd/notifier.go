@@ -0,0 +1,243 @@
+// Package systemd integrates the health.Monitor with systemd units of Type=notify (see sd_notify(3)).
+//
+//	notifier, _ := systemd.NewNotifier(systemd.WithLogger(logger))
+//	notifier.Start(monitor)
+//	monitor.Start()
+//
+//	// the stoppable registered last is stopped first, hence the Notifier has to be registered
+//	// after all other stoppables to send STOPPING=1 as the first step of the shutdown
+//	shutdownHandler.Register(notifier)
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+	"github.com/rs/zerolog"
+)
+
+// Notifier sends notifications about the state of the service to systemd via the socket given by $NOTIFY_SOCKET.
+//   - READY=1 as soon as the Liveness probe of the monitor reports for the first time that the service is not unhealthy
+//   - WATCHDOG=1 periodically, but only while the Liveness probe reports that the service is not unhealthy
+//   - STATUS=... with a summary on each change of the status
+//   - STOPPING=1 when the Notifier is stopped (i.e. as part of the shutdown)
+//
+// In case $NOTIFY_SOCKET is not set (the service does not run as systemd unit) no notifications are sent.
+type Notifier struct {
+	socket           string
+	watchdogInterval time.Duration
+	logger           zerolog.Logger
+
+	// the current status of each check, used to build the summary
+	checkStatus map[string]health.Status
+	ready       bool
+	monitor     *health.Monitor
+	unsubscribe health.UnsubscribeFun
+	stopChan    chan struct{}
+	wg          sync.WaitGroup
+	mux         sync.Mutex
+}
+
+// NewNotifier creates a Notifier, the socket and the watchdog interval are taken from the environment per default
+func NewNotifier(options ...Option) (*Notifier, error) {
+	watchdogInterval, err := watchdogIntervalFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	notifier := &Notifier{
+		socket:           os.Getenv("NOTIFY_SOCKET"),
+		watchdogInterval: watchdogInterval,
+		checkStatus:      make(map[string]health.Status),
+	}
+
+	// apply the options
+	for _, opt := range options {
+		opt(notifier)
+	}
+
+	if notifier.watchdogInterval < 0 {
+		return nil, fmt.Errorf("Unable to create a Notifier with a watchdog interval of %s", notifier.watchdogInterval)
+	}
+	return notifier, nil
+}
+
+// watchdogIntervalFromEnv returns the interval the watchdog has to be informed in, 0 in case the watchdog is not enabled for this process
+func watchdogIntervalFromEnv() (time.Duration, error) {
+	usec := os.Getenv("WATCHDOG_USEC")
+	if len(usec) == 0 {
+		return 0, nil
+	}
+
+	// the watchdog might be meant for another process (e.g. the parent process)
+	if pid := os.Getenv("WATCHDOG_PID"); len(pid) > 0 && pid != strconv.Itoa(os.Getpid()) {
+		return 0, nil
+	}
+
+	timeout, err := strconv.ParseInt(usec, 10, 64)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("Unable to parse WATCHDOG_USEC '%s'", usec)
+	}
+
+	// as recommended by sd_watchdog_enabled(3) the watchdog is informed twice per timeout
+	return time.Duration(timeout) * time.Microsecond / 2, nil
+}
+
+// Enabled returns true in case the notifications are sent (i.e. the service runs as systemd unit)
+func (n *Notifier) Enabled() bool {
+	return len(n.socket) > 0
+}
+
+// Notify sends the given state assignments (e.g. "READY=1") to systemd
+func (n *Notifier) Notify(states ...string) error {
+	if !n.Enabled() {
+		return nil
+	}
+
+	socket := n.socket
+	// a leading @ denotes a socket in the abstract namespace
+	if strings.HasPrefix(socket, "@") {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return fmt.Errorf("Unable to connect to the notify socket '%s': %w", n.socket, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte(strings.Join(states, "\n"))); err != nil {
+		return fmt.Errorf("Unable to notify systemd: %w", err)
+	}
+	return nil
+}
+
+// Start subscribes to the events of the given monitor and starts the watchdog.
+// In case the monitor already reported that the service is alive READY=1 is sent right away.
+// Only the Liveness probe is considered, hence a failing check of the Readiness probe (e.g. of a downstream service)
+// does not result in a restart of the service by systemd.
+func (n *Notifier) Start(monitor *health.Monitor) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	if n.stopChan != nil {
+		return
+	}
+
+	n.stopChan = make(chan struct{})
+	n.monitor = monitor
+	n.unsubscribe = monitor.Subscribe(n.onEvent)
+
+	// the monitor might have been started before, hence the change of the status was already missed
+	if monitor.Evaluated() && n.isAlive() {
+		n.ready = true
+		if err := n.Notify("READY=1", "STATUS="+n.summary(monitor.Status())); err != nil {
+			n.logger.Error().Err(err).Msg("Unable to notify systemd about the status")
+		}
+	}
+
+	if n.watchdogInterval > 0 {
+		n.wg.Add(1)
+		go n.watchdog(monitor, n.stopChan)
+	}
+	n.logger.Info().Bool("enabled", n.Enabled()).Msgf("%s started", n)
+}
+
+// Stop sends STOPPING=1 and stops the watchdog. Hence the Notifier can be registered at the shutdown.ShutdownHandler.
+func (n *Notifier) Stop() error {
+	n.mux.Lock()
+	if n.stopChan != nil {
+		n.unsubscribe()
+		close(n.stopChan)
+		n.stopChan = nil
+	}
+	n.mux.Unlock()
+	n.wg.Wait()
+
+	return n.Notify("STOPPING=1", "STATUS=Stopping")
+}
+
+func (n *Notifier) String() string {
+	return "systemd notifier"
+}
+
+func (n *Notifier) onEvent(event health.Event) {
+	n.mux.Lock()
+	defer n.mux.Unlock()
+
+	// events that are delivered after the Notifier was stopped must not overwrite STOPPING=1
+	if n.stopChan == nil {
+		return
+	}
+
+	var states []string
+	if event.Type == health.CheckStatusChanged {
+		n.checkStatus[event.Check] = event.To
+	} else {
+		states = append(states, "STATUS="+n.summary(event.To))
+	}
+
+	// the service might become alive without a change of the overall status (e.g. a Readiness check still fails)
+	if !n.ready && n.isAlive() {
+		n.ready = true
+		states = append([]string{"READY=1"}, states...)
+	}
+	if len(states) == 0 {
+		return
+	}
+
+	if err := n.Notify(states...); err != nil {
+		n.logger.Error().Err(err).Msg("Unable to notify systemd about the status")
+	}
+}
+
+// isAlive returns true in case the Liveness probe of the monitor reports that the service is not unhealthy
+func (n *Notifier) isAlive() bool {
+	return n.monitor.ProbeStatus(health.Liveness) != health.StatusUnhealthy
+}
+
+// summary returns a human readable summary of the given status and the failing checks
+func (n *Notifier) summary(status health.Status) string {
+	var failing []string
+	for name, checkStatus := range n.checkStatus {
+		if checkStatus != health.StatusHealthy {
+			failing = append(failing, name)
+		}
+	}
+
+	if len(failing) == 0 {
+		return fmt.Sprintf("Service is %s", status)
+	}
+	sort.Strings(failing)
+	return fmt.Sprintf("Service is %s, failing checks: %s", status, strings.Join(failing, ", "))
+}
+
+func (n *Notifier) watchdog(monitor *health.Monitor, stopChan chan struct{}) {
+	defer n.wg.Done()
+
+	ticker := time.NewTicker(n.watchdogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-ticker.C:
+			// systemd restarts the service in case the pings are missing
+			if monitor.ProbeStatus(health.Liveness) == health.StatusUnhealthy {
+				n.logger.Warn().Msg("Service is not alive, the systemd watchdog is not informed")
+				continue
+			}
+			if err := n.Notify("WATCHDOG=1"); err != nil {
+				n.logger.Error().Err(err).Msg("Unable to inform the systemd watchdog")
+			}
+		}
+	}
+}
@@ -0,0 +1,257 @@
+package systemd
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ThomasObenaus/go-base/health"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listen creates a local notify socket and returns a channel that receives the notifications
+func listen(t *testing.T) (string, <-chan string) {
+	// the path of a unix socket is limited to about 100 characters, hence t.TempDir() can't be used
+	dir, err := os.MkdirTemp("", "sd")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	notifications := make(chan string, 100)
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			notifications <- string(buf[:n])
+		}
+	}()
+	return socket, notifications
+}
+
+func receive(t *testing.T, notifications <-chan string) string {
+	select {
+	case notification := <-notifications:
+		return notification
+	case <-time.After(time.Second * 5):
+		require.Fail(t, "No notification received")
+	}
+	return ""
+}
+
+func Test_Notify(t *testing.T) {
+
+	// GIVEN
+	socket, notifications := listen(t)
+	notifier, err := NewNotifier(WithSocket(socket))
+	require.NoError(t, err)
+
+	// WHEN
+	err = notifier.Notify("READY=1", "STATUS=Ready")
+
+	// THEN
+	assert.NoError(t, err)
+	assert.True(t, notifier.Enabled())
+	assert.Equal(t, "READY=1\nSTATUS=Ready", receive(t, notifications))
+}
+
+func Test_NotifyShouldBeDisabledWithoutSocket(t *testing.T) {
+
+	// GIVEN
+	notifier, err := NewNotifier(WithSocket(""))
+	require.NoError(t, err)
+
+	// WHEN
+	err = notifier.Notify("READY=1")
+
+	// THEN
+	assert.NoError(t, err)
+	assert.False(t, notifier.Enabled())
+}
+
+func Test_NotifierShouldSendReadyAndStatus(t *testing.T) {
+
+	// GIVEN
+	socket, notifications := listen(t)
+	notifier, err := NewNotifier(WithSocket(socket), WithWatchdogInterval(0))
+	require.NoError(t, err)
+	monitor, err := health.NewMonitor()
+	require.NoError(t, err)
+	dbErr := fmt.Errorf("connection refused")
+	db, err := health.NewSimpleCheck("db", func() error { return dbErr })
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(db))
+	notifier.Start(monitor)
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	assert.Equal(t, "STATUS=Service is unhealthy, failing checks: db", receive(t, notifications))
+
+	// WHEN
+	dbErr = nil
+	monitor.EvaluateNow()
+
+	// THEN
+	// the service is ready as soon as the check of the Liveness probe passed, the overall status follows
+	assert.Equal(t, "READY=1", receive(t, notifications))
+	assert.Equal(t, "STATUS=Service is healthy", receive(t, notifications))
+
+	// WHEN
+	err = notifier.Stop()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "STOPPING=1\nSTATUS=Stopping", receive(t, notifications))
+}
+
+func Test_NotifierShouldSendReadyWhenStartedAfterTheMonitor(t *testing.T) {
+
+	// GIVEN
+	socket, notifications := listen(t)
+	notifier, err := NewNotifier(WithSocket(socket), WithWatchdogInterval(0))
+	require.NoError(t, err)
+	monitor, err := health.NewMonitor()
+	require.NoError(t, err)
+	db, err := health.NewSimpleCheck("db", func() error { return nil })
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(db))
+	monitor.EvaluateNow()
+
+	// WHEN
+	notifier.Start(monitor)
+	defer notifier.Stop()
+
+	// THEN
+	assert.Equal(t, "READY=1\nSTATUS=Service is healthy", receive(t, notifications))
+}
+
+func Test_NotifierShouldNotSendReadyBeforeTheFirstEvaluation(t *testing.T) {
+
+	// GIVEN
+	socket, notifications := listen(t)
+	notifier, err := NewNotifier(WithSocket(socket), WithWatchdogInterval(0))
+	require.NoError(t, err)
+	monitor, err := health.NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN
+	notifier.Start(monitor)
+	err = notifier.Stop()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, "STOPPING=1\nSTATUS=Stopping", receive(t, notifications))
+}
+
+func Test_NotifierShouldPingWatchdogOnlyWhileHealthy(t *testing.T) {
+
+	// GIVEN
+	socket, notifications := listen(t)
+	notifier, err := NewNotifier(WithSocket(socket), WithWatchdogInterval(time.Millisecond*10))
+	require.NoError(t, err)
+	monitor, err := health.NewMonitor()
+	require.NoError(t, err)
+
+	// WHEN
+	notifier.Start(monitor)
+
+	// THEN
+	assert.Equal(t, "WATCHDOG=1", receive(t, notifications))
+
+	// WHEN
+	failing, err := health.NewSimpleCheck("db", func() error { return fmt.Errorf("connection refused") })
+	require.NoError(t, err)
+	require.NoError(t, monitor.Register(failing))
+	monitor.EvaluateNow()
+	assert.Equal(t, "STATUS=Service is unhealthy, failing checks: db", waitFor(t, notifications, "STATUS="))
+
+	// THEN
+	// a ping that was already in flight during the evaluation is skipped
+	time.Sleep(time.Millisecond * 20)
+	for len(notifications) > 0 {
+		<-notifications
+	}
+	select {
+	case notification := <-notifications:
+		assert.Fail(t, "Unexpected notification", notification)
+	case <-time.After(time.Millisecond * 100):
+	}
+	require.NoError(t, notifier.Stop())
+}
+
+func Test_NotifierShouldIgnoreFailingReadinessChecks(t *testing.T) {
+
+	// GIVEN
+	socket, notifications := listen(t)
+	notifier, err := NewNotifier(WithSocket(socket), WithWatchdogInterval(time.Millisecond*10))
+	require.NoError(t, err)
+	monitor, err := health.NewMonitor()
+	require.NoError(t, err)
+	downstream, err := health.NewSimpleCheck("downstream", func() error { return fmt.Errorf("connection refused") })
+	require.NoError(t, err)
+	require.NoError(t, monitor.RegisterCheck(downstream, health.ForProbes(health.Readiness)))
+	notifier.Start(monitor)
+	defer notifier.Stop()
+
+	// WHEN
+	monitor.EvaluateNow()
+
+	// THEN
+	waitFor(t, notifications, "READY=1")
+	assert.Equal(t, "STATUS=Service is unhealthy, failing checks: downstream", waitFor(t, notifications, "STATUS="))
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, "WATCHDOG=1", waitFor(t, notifications, "WATCHDOG=1"))
+	}
+}
+
+// waitFor returns the first notification with the given prefix, skipping all others
+func waitFor(t *testing.T, notifications <-chan string, prefix string) string {
+	for {
+		notification := receive(t, notifications)
+		if len(notification) >= len(prefix) && notification[:len(prefix)] == prefix {
+			return notification
+		}
+	}
+}
+
+func Test_WatchdogIntervalFromEnv(t *testing.T) {
+
+	// GIVEN
+	t.Setenv("WATCHDOG_USEC", "30000000")
+	t.Setenv("WATCHDOG_PID", fmt.Sprint(os.Getpid()))
+
+	// WHEN
+	interval, err := watchdogIntervalFromEnv()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, time.Second*15, interval)
+
+	// WHEN
+	t.Setenv("WATCHDOG_PID", "1")
+	interval, err = watchdogIntervalFromEnv()
+
+	// THEN
+	assert.NoError(t, err)
+	assert.Equal(t, time.Duration(0), interval)
+
+	// WHEN
+	t.Setenv("WATCHDOG_PID", "")
+	t.Setenv("WATCHDOG_USEC", "invalid")
+	_, err = watchdogIntervalFromEnv()
+
+	// THEN
+	assert.Error(t, err)
+}
@@ -0,0 +1,33 @@
+package systemd
+
+import (
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Option represents an option for the Notifier
+type Option func(n *Notifier)
+
+// WithLogger specifies the logger that should be used
+func WithLogger(logger zerolog.Logger) Option {
+	return func(n *Notifier) {
+		n.logger = logger
+	}
+}
+
+// WithSocket specifies the socket the notifications are sent to (default $NOTIFY_SOCKET).
+// An empty socket disables the notifications.
+func WithSocket(socket string) Option {
+	return func(n *Notifier) {
+		n.socket = socket
+	}
+}
+
+// WithWatchdogInterval specifies the interval WATCHDOG=1 is sent in (default half of $WATCHDOG_USEC).
+// An interval of 0 disables the watchdog.
+func WithWatchdogInterval(interval time.Duration) Option {
+	return func(n *Notifier) {
+		n.watchdogInterval = interval
+	}
+}
@@ -5,6 +5,7 @@
 package mock_health
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -60,3 +61,91 @@ func (mr *MockCheckMockRecorder) String() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockCheck)(nil).String))
 }
+
+// MockContextCheck is a mock of ContextCheck interface.
+type MockContextCheck struct {
+	ctrl     *gomock.Controller
+	recorder *MockContextCheckMockRecorder
+}
+
+// MockContextCheckMockRecorder is the mock recorder for MockContextCheck.
+type MockContextCheckMockRecorder struct {
+	mock *MockContextCheck
+}
+
+// NewMockContextCheck creates a new mock instance.
+func NewMockContextCheck(ctrl *gomock.Controller) *MockContextCheck {
+	mock := &MockContextCheck{ctrl: ctrl}
+	mock.recorder = &MockContextCheckMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockContextCheck) EXPECT() *MockContextCheckMockRecorder {
+	return m.recorder
+}
+
+// IsHealthy mocks base method.
+func (m *MockContextCheck) IsHealthy(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsHealthy", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IsHealthy indicates an expected call of IsHealthy.
+func (mr *MockContextCheckMockRecorder) IsHealthy(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsHealthy", reflect.TypeOf((*MockContextCheck)(nil).IsHealthy), ctx)
+}
+
+// String mocks base method.
+func (m *MockContextCheck) String() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "String")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// String indicates an expected call of String.
+func (mr *MockContextCheckMockRecorder) String() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "String", reflect.TypeOf((*MockContextCheck)(nil).String))
+}
+
+// MockDetailsProvider is a mock of DetailsProvider interface.
+type MockDetailsProvider struct {
+	ctrl     *gomock.Controller
+	recorder *MockDetailsProviderMockRecorder
+}
+
+// MockDetailsProviderMockRecorder is the mock recorder for MockDetailsProvider.
+type MockDetailsProviderMockRecorder struct {
+	mock *MockDetailsProvider
+}
+
+// NewMockDetailsProvider creates a new mock instance.
+func NewMockDetailsProvider(ctrl *gomock.Controller) *MockDetailsProvider {
+	mock := &MockDetailsProvider{ctrl: ctrl}
+	mock.recorder = &MockDetailsProviderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDetailsProvider) EXPECT() *MockDetailsProviderMockRecorder {
+	return m.recorder
+}
+
+// Details mocks base method.
+func (m *MockDetailsProvider) Details() map[string]interface{} {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Details")
+	ret0, _ := ret[0].(map[string]interface{})
+	return ret0
+}
+
+// Details indicates an expected call of Details.
+func (mr *MockDetailsProviderMockRecorder) Details() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Details", reflect.TypeOf((*MockDetailsProvider)(nil).Details))
+}